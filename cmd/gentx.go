@@ -19,9 +19,14 @@ var gentxCmd = &cobra.Command{
 		senderCount, _ := cmd.Flags().GetInt("sender-count")
 		txCount, _ := cmd.Flags().GetInt("tx-count")
 		txType, _ := cmd.Flags().GetString("tx-type")
+		params, _ := cmd.Flags().GetStringToString("workload-opt")
+		txEnvelope, _ := cmd.Flags().GetString("tx-envelope")
+		fee, _ := cmd.Flags().GetString("fee")
+		tipTiers, _ := cmd.Flags().GetString("tip-tiers")
+		accessList, _ := cmd.Flags().GetString("access-list")
 		txStoreDir, _ := cmd.Flags().GetString("tx-store-dir")
 
-		gentx.GenTx(httpRpc, faucetPrivateKey, senderCount, txCount, txType, txStoreDir)
+		gentx.GenTx(httpRpc, faucetPrivateKey, senderCount, txCount, txType, params, txEnvelope, fee, tipTiers, accessList, txStoreDir)
 		fmt.Println("gentx called")
 	},
 }
@@ -8,7 +8,12 @@ func OptionsForGeneration(cmd *cobra.Command) {
 	cmd.Flags().StringP("faucet-private-key", "f", "0xfffdbb37105441e14b0ee6330d855d8504ff39e705c3afa8f859ac9865f99306", "Private key of a faucet account")
 	cmd.Flags().IntP("sender-count", "s", 4, "The number of senders of generated transactions")
 	cmd.Flags().IntP("tx-count", "t", 100000, "The number of tx count each sender will broadcast")
-	cmd.Flags().StringP("tx-type", "p", "simple", "Transaction type: simple, erc20, or uniswap")
+	cmd.Flags().StringP("tx-type", "p", "simple", "Transaction type, run the workloads command to list them")
+	cmd.Flags().StringToStringP("workload-opt", "w", map[string]string{}, "Workload specific parameters as key=value pairs")
+	cmd.Flags().String("tx-envelope", "auto", "Transaction envelope: legacy, 2930 or 1559, or a mix such as legacy=20,1559=80 (auto picks 1559 if the chain supports it)")
+	cmd.Flags().String("fee", "multiplier=32", "Fee strategy: fixed=<gwei>, multiplier=<factor of eth_gasPrice>, history=<tip percentile of eth_feeHistory> or track=<percentile> re-pricing while sending, optionally followed by cap=<gwei>")
+	cmd.Flags().String("tip-tiers", "", "Priority fees in gwei of groups of senders, e.g. 1,2,5,10 with sender i bidding tier i modulo 4, or random=1:10 for random tips")
+	cmd.Flags().String("access-list", "none", "Access lists of the transactions: none, rpc (eth_createAccessList) or local (computed by the workload)")
 }
 
 func OptionsForTxStore(cmd *cobra.Command) {
@@ -21,15 +21,28 @@ var runCmd = &cobra.Command{
 		senderCount, _ := cmd.Flags().GetInt("sender-count")
 		txCount, _ := cmd.Flags().GetInt("tx-count")
 		txType, _ := cmd.Flags().GetString("tx-type")
+		params, _ := cmd.Flags().GetStringToString("workload-opt")
+		txEnvelope, _ := cmd.Flags().GetString("tx-envelope")
+		fee, _ := cmd.Flags().GetString("fee")
+		tipTiers, _ := cmd.Flags().GetString("tip-tiers")
+		accessList, _ := cmd.Flags().GetString("access-list")
+		invalidRatio, _ := cmd.Flags().GetFloat64("invalid-ratio")
+		invalidKinds, _ := cmd.Flags().GetString("invalid-kinds")
+		accessListAB, _ := cmd.Flags().GetBool("access-list-ab")
 		mempool, _ := cmd.Flags().GetInt("mempool")
 		poolSize, _ := cmd.Flags().GetInt("client-pool-size")
+		batchSize, _ := cmd.Flags().GetInt("batch-size")
 
-		run.Run(httpRpc, wsRpc, faucetPrivateKey, senderCount, txCount, txType, mempool, poolSize)
+		run.Run(httpRpc, wsRpc, faucetPrivateKey, senderCount, txCount, txType, params, txEnvelope, fee, tipTiers, accessList, accessListAB, invalidRatio, invalidKinds, mempool, poolSize, batchSize)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(runCmd)
 	option.OptionsForGeneration(runCmd)
+	runCmd.Flags().Float64("invalid-ratio", 0, "Fraction of the sent txs which are invalid and must be rejected by the node, e.g. 0.1")
+	runCmd.Flags().String("invalid-kinds", "all", "Kinds of invalid txs: bad-signature, wrong-chain-id, insufficient-balance, intrinsic-gas and oversize")
+	runCmd.Flags().Bool("access-list-ab", false, "Run the workload without and then with access lists and report the TPS difference")
 	runCmd.Flags().Int("client-pool-size", 800, "HTTP client pool size for broadcasting (default 800)")
+	runCmd.Flags().Int("batch-size", 1, "Senders whose next txs share a JSON-RPC batch request, one tx each, 1 sends each tx in its own request")
 }
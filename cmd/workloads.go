@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/0glabs/evmchainbench/lib/generator"
+	"github.com/spf13/cobra"
+)
+
+var workloadsCmd = &cobra.Command{
+	Use:   "workloads",
+	Short: "List the available transaction types",
+	Long:  "List the available transaction types and the parameters they accept via --workload-opt",
+	Run: func(cmd *cobra.Command, args []string) {
+		for _, w := range generator.Workloads() {
+			fmt.Printf("%-12s %s\n", w.Name(), w.Describe())
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(workloadsCmd)
+}
@@ -0,0 +1,77 @@
+; Hand-written assembly of callchain.sol, see tools/asmmeta for the syntax.
+
+0 CALLDATALOAD 0xe0 SHR
+CALLVALUE @revert JUMPI
+DUP1 SEL(hop(uint256,uint256,uint256,uint256)) EQ @hop JUMPI
+DUP1 SEL(setPeers(address[])) EQ @setPeers JUMPI
+DUP1 SEL(peers(uint256)) EQ @peers JUMPI
+DUP1 SEL(counts(uint256)) EQ @counts JUMPI
+revert:
+0 DUP1 REVERT
+
+counts:
+4 CALLDATALOAD 0 MSTORE 1 32 MSTORE 64 0 SHA3 SLOAD 0 MSTORE 32 0 RETURN
+
+peers:
+4 CALLDATALOAD DUP1 0 SLOAD GT ISZERO @revert JUMPI
+0 0 MSTORE 32 0 SHA3 ADD SLOAD 0 MSTORE 32 0 RETURN
+
+; [sel, n, src, base, i]
+setPeers:
+4 CALLDATALOAD 4 ADD
+DUP1 CALLDATALOAD
+DUP1 0 SSTORE
+SWAP1 32 ADD
+0 0 MSTORE 32 0 SHA3
+0
+peerLoop:
+DUP4 DUP2 LT ISZERO @peerDone JUMPI
+DUP1 32 MUL DUP4 ADD CALLDATALOAD
+DUP2 DUP4 ADD SSTORE
+1 ADD
+@peerLoop JUMP
+peerDone:
+STOP
+
+; [sel, slot, sum, i]
+hop:
+68 CALLDATALOAD 2 LT @revert JUMPI
+100 CALLDATALOAD 0 MSTORE 1 32 MSTORE 64 0 SHA3
+DUP1 SLOAD
+2 68 CALLDATALOAD EQ @hopRead JUMPI
+1 ADD DUP1 DUP3 SSTORE
+hopRead:
+4 CALLDATALOAD ISZERO @hopReturn JUMPI
+SEL(hop(uint256,uint256,uint256,uint256)) 0xe0 SHL 0x100 MSTORE
+1 4 CALLDATALOAD SUB 0x104 MSTORE
+36 CALLDATALOAD 0x124 MSTORE
+68 CALLDATALOAD 0x144 MSTORE
+100 CALLDATALOAD 0x164 MSTORE
+0
+fanLoop:
+36 CALLDATALOAD DUP2 LT ISZERO @fanDone JUMPI
+0 SLOAD DUP2 4 CALLDATALOAD ADD MOD
+0 0 MSTORE 32 0 SHA3 ADD SLOAD
+68 CALLDATALOAD DUP1 ISZERO @doCall JUMPI
+1 EQ @doDelegate JUMPI
+32 0x200 0x84 0x100 DUP5 GAS STATICCALL
+@afterCall JUMP
+doCall:
+POP
+32 0x200 0x84 0x100 0 DUP6 GAS CALL
+@afterCall JUMP
+doDelegate:
+32 0x200 0x84 0x100 DUP5 GAS DELEGATECALL
+afterCall:
+ISZERO @bubble JUMPI
+POP
+RETURNDATASIZE 32 EQ ISZERO @revert JUMPI
+0x200 MLOAD DUP3 ADD SWAP2 POP
+1 ADD
+@fanLoop JUMP
+fanDone:
+POP
+hopReturn:
+0 MSTORE 32 0 RETURN
+bubble:
+RETURNDATASIZE 0 0 RETURNDATACOPY RETURNDATASIZE 0 REVERT
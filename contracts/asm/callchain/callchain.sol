@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: MIT
+
+// The readable equivalent of CallChain.asm, which is what the benchmark
+// deploys. This file is not compiled, "make asm-check" runs CallChain.asm
+// through CallChain.abi instead.
+
+pragma solidity ^0.8.0;
+
+/**
+ * @dev One of several deployed copies calling each other. {hop} bumps the
+ * count of `key` and, until `depth` reaches 0, calls {hop} of `fanout` peers
+ * with one less depth. Peers are picked by depth so that the chain is the
+ * same under DELEGATECALL, which runs them on the storage of the caller.
+ *
+ * With mode STATIC the counts are only read, as STATICCALL forbids writes.
+ */
+contract CallChain {
+    uint256 constant CALL = 0;
+    uint256 constant DELEGATECALL = 1;
+    uint256 constant STATICCALL = 2;
+
+    address[] public peers;
+
+    mapping (uint256 => uint256) public counts;
+
+    function setPeers(address[] calldata _peers) external {
+        // like peers = _peers, but the tail of a longer list is not cleared
+        assembly {
+            sstore(peers.slot, _peers.length)
+            mstore(0, peers.slot)
+            let base := keccak256(0, 32)
+            for { let i := 0 } lt(i, _peers.length) { i := add(i, 1) } {
+                sstore(add(base, i), calldataload(add(_peers.offset, mul(i, 32))))
+            }
+        }
+    }
+
+    function hop(uint256 depth, uint256 fanout, uint256 mode, uint256 key) external returns (uint256 sum) {
+        require(mode <= STATICCALL);
+        if (mode != STATICCALL) {
+            unchecked {
+                counts[key] += 1;
+            }
+        }
+        sum = counts[key];
+        if (depth == 0) {
+            return sum;
+        }
+
+        bytes memory data = abi.encodeCall(this.hop, (depth - 1, fanout, mode, key));
+        for (uint256 i = 0; i < fanout; i++) {
+            assembly {
+                // like peers[(depth + i) % peers.length], but an empty list
+                // reads its first slot instead of failing
+                mstore(0, peers.slot)
+                let next := sload(add(keccak256(0, 32), mod(add(depth, i), sload(peers.slot))))
+                let success
+                switch mode
+                case 0 {
+                    success := call(gas(), next, 0, add(data, 32), mload(data), 0, 32)
+                }
+                case 1 {
+                    success := delegatecall(gas(), next, add(data, 32), mload(data), 0, 32)
+                }
+                default {
+                    success := staticcall(gas(), next, add(data, 32), mload(data), 0, 32)
+                }
+                if iszero(success) {
+                    returndatacopy(0, 0, returndatasize())
+                    revert(0, returndatasize())
+                }
+                // the peer must return exactly the one word of the sum
+                if iszero(eq(returndatasize(), 32)) {
+                    revert(0, 0)
+                }
+                sum := add(sum, mload(0))
+            }
+        }
+    }
+}
@@ -0,0 +1,54 @@
+; Hand-written assembly of computebench.sol, see tools/asmmeta for the syntax.
+
+0 CALLDATALOAD 0xe0 SHR
+CALLVALUE @revert JUMPI
+DUP1 SEL(keccakLoop(uint256)) EQ @keccakLoop JUMPI
+DUP1 SEL(arithLoop(uint256)) EQ @arithLoop JUMPI
+DUP1 SEL(memoryLoop(uint256,uint256)) EQ @memoryLoop JUMPI
+revert:
+0 DUP1 REVERT
+
+; [h, rounds, i]
+keccakLoop:
+0 4 CALLDATALOAD 0
+hashLoop:
+DUP2 DUP2 LT ISZERO @hashDone JUMPI
+DUP3 0 MSTORE
+DUP1 32 MSTORE
+64 0 SHA3 SWAP3 POP
+1 ADD
+@hashLoop JUMP
+hashDone:
+POP POP 0 MSTORE 32 0 RETURN
+
+; [x, rounds, i]
+arithLoop:
+4 CALLDATALOAD DUP1 0
+arithNext:
+DUP2 DUP2 LT ISZERO @arithDone JUMPI
+0xffffffffffffffffffffffffffffff61 DUP2 0x9e3779b97f4a7c15 DUP6 MUL ADDMOD
+0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f DUP2 DUP1 MULMOD SWAP1 POP
+DUP1 7 SHR XOR
+3 DUP3 EXP ADD
+3 DUP2 DIV ADD
+SWAP3 POP
+1 ADD
+@arithNext JUMP
+arithDone:
+POP POP 0 MSTORE 32 0 RETURN
+
+; [sum, words, rounds, i]
+memoryLoop:
+4 CALLDATALOAD ISZERO @revert JUMPI
+0 4 CALLDATALOAD 36 CALLDATALOAD 0
+1 32 32 DUP6 MUL SUB MSTORE
+memNext:
+DUP2 DUP2 LT ISZERO @memDone JUMPI
+32 DUP4 7919 DUP4 MUL MOD MUL
+DUP2 DUP2 MLOAD ADD
+DUP1 DUP3 MSTORE
+DUP6 ADD SWAP5 POP POP
+1 ADD
+@memNext JUMP
+memDone:
+POP POP POP 0 MSTORE 32 0 RETURN
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: MIT
+
+// The readable equivalent of ComputeBench.asm, which is what the benchmark
+// deploys. This file is not compiled, "make asm-check" runs ComputeBench.asm
+// through ComputeBench.abi instead.
+
+pragma solidity ^0.8.0;
+
+/**
+ * @dev Burns gas in tight loops of a single class of opcodes without touching
+ * state, so that the cost of a transaction is pure execution.
+ */
+contract ComputeBench {
+    function keccakLoop(uint256 rounds) external pure returns (bytes32 h) {
+        assembly {
+            for { let i := 0 } lt(i, rounds) { i := add(i, 1) } {
+                mstore(0, h)
+                mstore(32, i)
+                h := keccak256(0, 64)
+            }
+        }
+    }
+
+    function arithLoop(uint256 rounds) external pure returns (uint256 x) {
+        assembly {
+            x := rounds
+            for { let i := 0 } lt(i, rounds) { i := add(i, 1) } {
+                x := addmod(mul(x, 0x9e3779b97f4a7c15), i, 0xffffffffffffffffffffffffffffff61)
+                x := mulmod(x, x, 0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f)
+                x := xor(x, shr(7, x))
+                x := add(x, exp(i, 3))
+                x := add(x, div(x, 3))
+            }
+        }
+    }
+
+    function memoryLoop(uint256 words, uint256 rounds) external pure returns (uint256 sum) {
+        require(words > 0);
+        assembly {
+            // work from offset 0, expanded to the requested size upfront, and
+            // return from here as the free memory pointer gets overwritten
+            mstore(sub(mul(words, 32), 32), 1)
+            for { let i := 0 } lt(i, rounds) { i := add(i, 1) } {
+                let offset := mul(mod(mul(i, 7919), words), 32)
+                let value := add(mload(offset), i)
+                mstore(offset, value)
+                sum := add(sum, value)
+            }
+            mstore(0, sum)
+            return(0, 32)
+        }
+    }
+}
@@ -0,0 +1,14 @@
+; Hand-written assembly of contention.sol, see tools/asmmeta for the syntax.
+
+0 CALLDATALOAD 0xe0 SHR
+CALLVALUE @revert JUMPI
+DUP1 SEL(touch(uint256)) EQ @touch JUMPI
+DUP1 SEL(slots(uint256)) EQ @slots JUMPI
+revert:
+0 DUP1 REVERT
+
+touch:
+4 CALLDATALOAD 0 MSTORE 0 32 MSTORE 64 0 SHA3 DUP1 SLOAD 1 ADD SWAP1 SSTORE STOP
+
+slots:
+4 CALLDATALOAD 0 MSTORE 0 32 MSTORE 64 0 SHA3 SLOAD 0 MSTORE 32 0 RETURN
@@ -0,0 +1,21 @@
+// SPDX-License-Identifier: MIT
+
+// The readable equivalent of Contention.asm, which is what the benchmark
+// deploys. This file is not compiled, "make asm-check" runs Contention.asm
+// through Contention.abi instead.
+
+pragma solidity ^0.8.0;
+
+/**
+ * @dev Keyed counters used to dial in state contention: two transactions
+ * conflict exactly when they {touch} the same key.
+ */
+contract Contention {
+    mapping (uint256 => uint256) public slots;
+
+    function touch(uint256 key) external {
+        unchecked {
+            slots[key] += 1;
+        }
+    }
+}
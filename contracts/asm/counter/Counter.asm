@@ -0,0 +1,25 @@
+; Hand-written assembly of counter.sol, see tools/asmmeta for the syntax.
+
+0 CALLDATALOAD 0xe0 SHR
+DUP1 SEL(increment()) EQ @increment JUMPI
+DUP1 SEL(incrementSender()) EQ @incrementSender JUMPI
+DUP1 SEL(count()) EQ @count JUMPI
+DUP1 SEL(counts(address)) EQ @counts JUMPI
+revert:
+0 DUP1 REVERT
+
+increment:
+CALLVALUE @revert JUMPI
+0 SLOAD 1 ADD 0 SSTORE STOP
+
+incrementSender:
+CALLVALUE @revert JUMPI
+CALLER 0 MSTORE 1 32 MSTORE 64 0 SHA3 DUP1 SLOAD 1 ADD SWAP1 SSTORE STOP
+
+count:
+CALLVALUE @revert JUMPI
+0 SLOAD 0 MSTORE 32 0 RETURN
+
+counts:
+CALLVALUE @revert JUMPI
+4 CALLDATALOAD 0xffffffffffffffffffffffffffffffffffffffff AND 0 MSTORE 1 32 MSTORE 64 0 SHA3 SLOAD 0 MSTORE 32 0 RETURN
@@ -0,0 +1,31 @@
+// SPDX-License-Identifier: MIT
+
+// The readable equivalent of Counter.asm, which is what the benchmark deploys.
+// This file is not compiled, "make asm-check" runs Counter.asm through
+// Counter.abi instead.
+
+pragma solidity ^0.8.0;
+
+/**
+ * @dev The cheapest possible contract call: bump a counter.
+ *
+ * {increment} makes every sender contend on a single storage slot while
+ * {incrementSender} gives every sender its own slot.
+ */
+contract Counter {
+    uint256 public count;
+
+    mapping (address => uint256) public counts;
+
+    function increment() external {
+        unchecked {
+            count += 1;
+        }
+    }
+
+    function incrementSender() external {
+        unchecked {
+            counts[msg.sender] += 1;
+        }
+    }
+}
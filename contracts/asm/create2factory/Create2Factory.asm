@@ -0,0 +1,15 @@
+; Hand-written assembly of create2factory.sol, see tools/asmmeta for the syntax.
+
+0 CALLDATALOAD 0xe0 SHR
+CALLVALUE @revert JUMPI
+DUP1 SEL(deploy(bytes32,bytes)) EQ @deploy JUMPI
+revert:
+0 DUP1 REVERT
+
+deploy:
+36 CALLDATALOAD 4 ADD
+DUP1 CALLDATALOAD
+DUP1 SWAP2 32 ADD 0 CALLDATACOPY
+4 CALLDATALOAD SWAP1 0 0 CREATE2
+DUP1 ISZERO @revert JUMPI
+0 MSTORE 32 0 RETURN
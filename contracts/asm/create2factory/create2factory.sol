@@ -0,0 +1,27 @@
+// SPDX-License-Identifier: MIT
+
+// The readable equivalent of Create2Factory.asm, which is what the benchmark
+// deploys. This file is not compiled, "make asm-check" runs Create2Factory.asm
+// through Create2Factory.abi instead.
+
+pragma solidity ^0.8.0;
+
+/**
+ * @dev Deploys arbitrary init code with CREATE2, reverting if the deployment
+ * fails, e.g. because the salt was used before.
+ */
+contract Create2Factory {
+    function deploy(bytes32 salt, bytes calldata initCode) external returns (address deployed) {
+        assembly {
+            // the init code sits at offset 0, so return from here as the free
+            // memory pointer gets overwritten
+            calldatacopy(0, initCode.offset, initCode.length)
+            deployed := create2(0, 0, initCode.length, salt)
+            if iszero(deployed) {
+                revert(0, 0)
+            }
+            mstore(0, deployed)
+            return(0, 32)
+        }
+    }
+}
@@ -0,0 +1,44 @@
+; Hand-written assembly of eventbench.sol, see tools/asmmeta for the syntax.
+
+0 CALLDATALOAD 0xe0 SHR
+CALLVALUE @revert JUMPI
+DUP1 SEL(emitLogs(uint256,uint256,uint256,uint256)) EQ @emitLogs JUMPI
+revert:
+0 DUP1 REVERT
+
+; [sel, j, base]
+emitLogs:
+36 CALLDATALOAD 4 LT @revert JUMPI
+0
+logLoop:
+4 CALLDATALOAD DUP2 LT ISZERO @logDone JUMPI
+100 CALLDATALOAD DUP2 4 MUL ADD
+36 CALLDATALOAD
+DUP1 0 EQ @log0 JUMPI
+DUP1 1 EQ @log1 JUMPI
+DUP1 2 EQ @log2 JUMPI
+DUP1 3 EQ @log3 JUMPI
+POP
+3 DUP2 ADD 2 DUP3 ADD 1 DUP4 ADD DUP4 68 CALLDATALOAD 0 LOG4
+@logNext JUMP
+log3:
+POP
+2 DUP2 ADD 1 DUP3 ADD DUP3 68 CALLDATALOAD 0 LOG3
+@logNext JUMP
+log2:
+POP
+1 DUP2 ADD DUP2 68 CALLDATALOAD 0 LOG2
+@logNext JUMP
+log1:
+POP
+DUP1 68 CALLDATALOAD 0 LOG1
+@logNext JUMP
+log0:
+POP
+68 CALLDATALOAD 0 LOG0
+logNext:
+POP
+1 ADD
+@logLoop JUMP
+logDone:
+STOP
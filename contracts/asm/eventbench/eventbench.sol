@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: MIT
+
+// The readable equivalent of EventBench.asm, which is what the benchmark
+// deploys. This file is not compiled, "make asm-check" runs EventBench.asm
+// through EventBench.abi instead.
+
+pragma solidity ^0.8.0;
+
+/**
+ * @dev Emits `count` logs of `topics` topics and `size` bytes of data. The
+ * topics of log j are `seed + 4j` onwards, so they vary from call to call
+ * with the seed.
+ */
+contract EventBench {
+    function emitLogs(uint256 count, uint256 topics, uint256 size, uint256 seed) external {
+        require(topics <= 4);
+        for (uint256 j = 0; j < count; j++) {
+            uint256 base;
+            unchecked {
+                base = seed + j * 4;
+            }
+            assembly {
+                // the data is untouched memory, which is all zeros
+                let ptr := mload(0x40)
+                switch topics
+                case 0 { log0(ptr, size) }
+                case 1 { log1(ptr, size, base) }
+                case 2 { log2(ptr, size, base, add(base, 1)) }
+                case 3 { log3(ptr, size, base, add(base, 1), add(base, 2)) }
+                default { log4(ptr, size, base, add(base, 1), add(base, 2), add(base, 3)) }
+            }
+        }
+    }
+}
@@ -0,0 +1,42 @@
+; Hand-written assembly of failbench.sol, see tools/asmmeta for the syntax.
+
+0 CALLDATALOAD 0xe0 SHR
+CALLVALUE @revert JUMPI
+DUP1 SEL(succeed()) EQ @succeed JUMPI
+DUP1 SEL(fail()) EQ @fail JUMPI
+DUP1 SEL(burn()) EQ @burn JUMPI
+DUP1 SEL(nest(uint256,bool)) EQ @nest JUMPI
+DUP1 SEL(count()) EQ @count JUMPI
+revert:
+0 DUP1 REVERT
+
+succeed:
+0 SLOAD 1 ADD 0 SSTORE STOP
+
+fail:
+0 SLOAD 1 ADD 0 SSTORE
+0 DUP1 REVERT
+
+burn:
+0 SLOAD 1 ADD 0 SSTORE
+@burn JUMP
+
+; [sel, depth, shouldFail]
+nest:
+4 CALLDATALOAD 36 CALLDATALOAD
+DUP2 @nestCall JUMPI
+0 SLOAD 1 ADD 0 SSTORE
+@revert JUMPI
+STOP
+nestCall:
+SEL(nest(uint256,bool)) 0xe0 SHL 0 MSTORE
+1 DUP3 SUB 4 MSTORE
+DUP1 36 MSTORE
+0 0 68 0 0 ADDRESS GAS CALL
+ISZERO @bubble JUMPI
+STOP
+bubble:
+RETURNDATASIZE 0 0 RETURNDATACOPY RETURNDATASIZE 0 REVERT
+
+count:
+0 SLOAD 0 MSTORE 32 0 RETURN
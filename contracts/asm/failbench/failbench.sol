@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: MIT
+
+// The readable equivalent of FailBench.asm, which is what the benchmark
+// deploys. This file is not compiled, "make asm-check" runs FailBench.asm
+// through FailBench.abi instead.
+
+pragma solidity ^0.8.0;
+
+/**
+ * @dev Calls that are included in a block but fail, to measure the cost of
+ * failed execution next to successful calls doing the same work.
+ *
+ * {fail} bumps the counter and hits `require(false)`, {burn} bumps it until
+ * the gas runs out and {nest} calls itself `depth` times before bumping it,
+ * reverting at the bottom if `shouldFail` is set so that the revert unwinds
+ * the whole call stack.
+ */
+contract FailBench {
+    uint256 public count;
+
+    function succeed() external {
+        unchecked {
+            count += 1;
+        }
+    }
+
+    function fail() external {
+        unchecked {
+            count += 1;
+        }
+        require(false);
+    }
+
+    function burn() external {
+        while (true) {
+            unchecked {
+                count += 1;
+            }
+        }
+    }
+
+    function nest(uint256 depth, bool shouldFail) external {
+        if (depth == 0) {
+            unchecked {
+                count += 1;
+            }
+            require(!shouldFail);
+            return;
+        }
+        (bool success, bytes memory reason) = address(this).call(abi.encodeCall(this.nest, (depth - 1, shouldFail)));
+        if (!success) {
+            assembly {
+                revert(add(reason, 32), mload(reason))
+            }
+        }
+    }
+}
@@ -0,0 +1,31 @@
+; Hand-written assembly of multicall.sol, see tools/asmmeta for the syntax.
+
+0 CALLDATALOAD 0xe0 SHR
+CALLVALUE @revert JUMPI
+DUP1 SEL(aggregate((address,bytes)[])) EQ @aggregate JUMPI
+revert:
+0 DUP1 REVERT
+
+; [sel, n, base, i]
+aggregate:
+4 CALLDATALOAD 4 ADD
+DUP1 CALLDATALOAD
+SWAP1 32 ADD
+0
+loop:
+DUP3 DUP2 LT ISZERO @done JUMPI
+; [.., tuple, target, bytes, len]
+DUP1 32 MUL DUP3 ADD CALLDATALOAD DUP3 ADD
+DUP1 CALLDATALOAD
+DUP2 32 ADD CALLDATALOAD DUP3 ADD
+DUP1 CALLDATALOAD
+DUP1 DUP3 32 ADD 0 CALLDATACOPY
+0 0 DUP3 0 0 DUP8 GAS CALL
+ISZERO @bubble JUMPI
+POP POP POP POP
+1 ADD
+@loop JUMP
+done:
+STOP
+bubble:
+RETURNDATASIZE 0 0 RETURNDATACOPY RETURNDATASIZE 0 REVERT
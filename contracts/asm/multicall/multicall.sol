@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: MIT
+
+// The readable equivalent of Multicall.asm, which is what the benchmark
+// deploys. This file is not compiled, "make asm-check" runs Multicall.asm
+// through Multicall.abi instead.
+
+pragma solidity ^0.8.0;
+
+/**
+ * @dev Performs many calls in a single transaction, as aggregators and
+ * wallets do, reverting with the reason of the first call failing.
+ *
+ * The calls are made by this contract, so ERC20 transfers of the caller's
+ * tokens need an allowance and `transferFrom`. Anyone can spend the tokens
+ * approved to it, it is meant for benchmarks only.
+ */
+contract Multicall {
+    struct Call {
+        address target;
+        bytes callData;
+    }
+
+    function aggregate(Call[] calldata calls) external {
+        for (uint256 i = 0; i < calls.length; i++) {
+            address target = calls[i].target;
+            bytes calldata callData = calls[i].callData;
+            assembly {
+                // the call data sits at offset 0 and the output is only
+                // copied back to revert with it
+                calldatacopy(0, callData.offset, callData.length)
+                if iszero(call(gas(), target, 0, 0, callData.length, 0, 0)) {
+                    returndatacopy(0, 0, returndatasize())
+                    revert(0, returndatasize())
+                }
+            }
+        }
+    }
+}
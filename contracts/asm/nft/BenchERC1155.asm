@@ -0,0 +1,85 @@
+; Hand-written assembly of nft.sol, see tools/asmmeta for the syntax.
+
+0 CALLDATALOAD 0xe0 SHR
+CALLVALUE @revert JUMPI
+DUP1 SEL(mint(address,uint256,uint256)) EQ @mint JUMPI
+DUP1 SEL(safeTransferFrom(address,address,uint256,uint256,bytes)) EQ @safeTransferFrom JUMPI
+DUP1 SEL(safeBatchTransferFrom(address,address,uint256[],uint256[],bytes)) EQ @safeBatchTransferFrom JUMPI
+DUP1 SEL(balanceOf(address,uint256)) EQ @balanceOf JUMPI
+revert:
+0 DUP1 REVERT
+
+; slot(addr, id): [.. addr id] -> [.. slot]
+slot:
+0 MSTORE 0 32 MSTORE 64 0 SHA3 32 MSTORE 0 MSTORE 64 0 SHA3
+SWAP1 JUMP
+
+mint:
+4 CALLDATALOAD 0xffffffffffffffffffffffffffffffffffffffff AND
+DUP1 ISZERO @revert JUMPI
+@mintRet DUP2 36 CALLDATALOAD @slot JUMP
+mintRet:
+DUP1 SLOAD 68 CALLDATALOAD ADD SWAP1 SSTORE
+36 CALLDATALOAD 0 MSTORE 68 CALLDATALOAD 32 MSTORE
+0 CALLER TOPIC(TransferSingle(address,address,address,uint256,uint256)) 64 0 LOG4
+STOP
+
+safeTransferFrom:
+4 CALLDATALOAD 0xffffffffffffffffffffffffffffffffffffffff AND
+DUP1 CALLER EQ ISZERO @revert JUMPI
+36 CALLDATALOAD 0xffffffffffffffffffffffffffffffffffffffff AND
+DUP1 ISZERO @revert JUMPI
+@stFromRet DUP3 68 CALLDATALOAD @slot JUMP
+stFromRet:
+DUP1 SLOAD
+100 CALLDATALOAD DUP1 DUP3 LT @revert JUMPI
+SWAP1 SUB SWAP1 SSTORE
+@stToRet DUP2 68 CALLDATALOAD @slot JUMP
+stToRet:
+DUP1 SLOAD 100 CALLDATALOAD ADD SWAP1 SSTORE
+68 CALLDATALOAD 0 MSTORE 100 CALLDATALOAD 32 MSTORE
+SWAP1 CALLER TOPIC(TransferSingle(address,address,address,uint256,uint256)) 64 0 LOG4
+STOP
+
+safeBatchTransferFrom:
+4 CALLDATALOAD 0xffffffffffffffffffffffffffffffffffffffff AND
+DUP1 CALLER EQ ISZERO @revert JUMPI
+36 CALLDATALOAD 0xffffffffffffffffffffffffffffffffffffffff AND
+DUP1 ISZERO @revert JUMPI
+68 CALLDATALOAD 4 ADD
+100 CALLDATALOAD 4 ADD
+DUP2 CALLDATALOAD
+DUP2 CALLDATALOAD DUP2 EQ ISZERO @revert JUMPI
+0
+batchLoop:
+DUP2 DUP2 LT ISZERO @batchDone JUMPI
+DUP1 32 MUL 32 ADD
+DUP1 DUP6 ADD CALLDATALOAD
+SWAP1 DUP5 ADD CALLDATALOAD
+@bFromRet DUP9 DUP4 @slot JUMP
+bFromRet:
+DUP1 SLOAD
+DUP3 DUP2 LT @revert JUMPI
+DUP3 SWAP1 SUB SWAP1 SSTORE
+@bToRet DUP8 DUP4 @slot JUMP
+bToRet:
+DUP1 SLOAD DUP3 ADD SWAP1 SSTORE
+POP POP 1 ADD
+@batchLoop JUMP
+batchDone:
+POP
+32 MUL 32 ADD
+0x40 0 MSTORE
+DUP1 0x40 ADD 32 MSTORE
+DUP1 DUP4 0x40 CALLDATACOPY
+DUP1 DUP3 DUP3 0x40 ADD CALLDATACOPY
+2 MUL 0x40 ADD
+SWAP2 POP POP
+SWAP2 SWAP1 SWAP2
+CALLER SWAP1 TOPIC(TransferBatch(address,address,address,uint256[],uint256[])) SWAP1 0 LOG4
+STOP
+
+balanceOf:
+@balRet 4 CALLDATALOAD 0xffffffffffffffffffffffffffffffffffffffff AND 36 CALLDATALOAD @slot JUMP
+balRet:
+SLOAD 0 MSTORE 32 0 RETURN
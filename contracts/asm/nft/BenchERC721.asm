@@ -0,0 +1,63 @@
+; Hand-written assembly of nft.sol, see tools/asmmeta for the syntax.
+
+0 CALLDATALOAD 0xe0 SHR
+CALLVALUE @revert JUMPI
+DUP1 SEL(mint(address,uint256)) EQ @mint JUMPI
+DUP1 SEL(mintBatch(address,uint256,uint256)) EQ @mintBatch JUMPI
+DUP1 SEL(transferFrom(address,address,uint256)) EQ @transferFrom JUMPI
+DUP1 SEL(ownerOf(uint256)) EQ @ownerOf JUMPI
+DUP1 SEL(balanceOf(address)) EQ @balanceOf JUMPI
+revert:
+0 DUP1 REVERT
+
+mint:
+4 CALLDATALOAD 0xffffffffffffffffffffffffffffffffffffffff AND
+DUP1 ISZERO @revert JUMPI
+36 CALLDATALOAD
+DUP1 0 MSTORE 0 32 MSTORE 64 0 SHA3
+DUP1 SLOAD @revert JUMPI
+DUP3 SWAP1 SSTORE
+DUP2 0 MSTORE 1 32 MSTORE 64 0 SHA3
+DUP1 SLOAD 1 ADD SWAP1 SSTORE
+SWAP1 0 TOPIC(Transfer(address,address,uint256)) 0 0 LOG4
+STOP
+
+mintBatch:
+4 CALLDATALOAD 0xffffffffffffffffffffffffffffffffffffffff AND
+DUP1 ISZERO @revert JUMPI
+36 CALLDATALOAD
+DUP1 68 CALLDATALOAD ADD
+mintLoop:
+DUP1 DUP3 LT ISZERO @mintDone JUMPI
+DUP2 0 MSTORE 0 32 MSTORE 64 0 SHA3
+DUP1 SLOAD @revert JUMPI
+DUP4 SWAP1 SSTORE
+DUP2 DUP4 0 TOPIC(Transfer(address,address,uint256)) 0 0 LOG4
+SWAP1 1 ADD SWAP1
+@mintLoop JUMP
+mintDone:
+POP POP
+0 MSTORE 1 32 MSTORE 64 0 SHA3
+DUP1 SLOAD 68 CALLDATALOAD ADD SWAP1 SSTORE
+STOP
+
+transferFrom:
+4 CALLDATALOAD 0xffffffffffffffffffffffffffffffffffffffff AND
+DUP1 CALLER EQ ISZERO @revert JUMPI
+36 CALLDATALOAD 0xffffffffffffffffffffffffffffffffffffffff AND
+DUP1 ISZERO @revert JUMPI
+68 CALLDATALOAD
+DUP1 0 MSTORE 0 32 MSTORE 64 0 SHA3
+DUP1 SLOAD DUP5 EQ ISZERO @revert JUMPI
+DUP3 SWAP1 SSTORE
+DUP3 0 MSTORE 1 32 MSTORE 64 0 SHA3 DUP1 SLOAD 1 SWAP1 SUB SWAP1 SSTORE
+DUP2 0 MSTORE 1 32 MSTORE 64 0 SHA3 DUP1 SLOAD 1 ADD SWAP1 SSTORE
+DUP1 DUP3 DUP5 TOPIC(Transfer(address,address,uint256)) 0 0 LOG4
+STOP
+
+ownerOf:
+4 CALLDATALOAD 0 MSTORE 0 32 MSTORE 64 0 SHA3 SLOAD 0 MSTORE 32 0 RETURN
+
+balanceOf:
+4 CALLDATALOAD 0xffffffffffffffffffffffffffffffffffffffff AND
+0 MSTORE 1 32 MSTORE 64 0 SHA3 SLOAD 0 MSTORE 32 0 RETURN
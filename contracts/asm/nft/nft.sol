@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: MIT
+
+// The readable equivalent of BenchERC721.asm and BenchERC1155.asm, which are
+// what the benchmark deploys. This file is not compiled, "make asm-check" runs
+// the listings through their ABIs instead.
+
+pragma solidity ^0.8.0;
+
+/**
+ * @dev Minimal ERC721 for benchmarking. Anyone can mint and only the owner
+ * can move a token, approvals and receiver checks are left out on purpose
+ * so that a transfer costs the same as the state changes it makes.
+ */
+contract BenchERC721 {
+    mapping (uint256 => address) private _owners;
+
+    mapping (address => uint256) private _balances;
+
+    event Transfer(address indexed from, address indexed to, uint256 indexed tokenId);
+
+    function balanceOf(address owner) external view returns (uint256) {
+        return _balances[owner];
+    }
+
+    function ownerOf(uint256 tokenId) external view returns (address) {
+        return _owners[tokenId];
+    }
+
+    function mint(address to, uint256 tokenId) external {
+        require(to != address(0));
+        require(_owners[tokenId] == address(0));
+
+        _owners[tokenId] = to;
+        unchecked {
+            _balances[to] += 1;
+        }
+
+        emit Transfer(address(0), to, tokenId);
+    }
+
+    function mintBatch(address to, uint256 startId, uint256 count) external {
+        require(to != address(0));
+
+        unchecked {
+            for (uint256 tokenId = startId; tokenId < startId + count; tokenId++) {
+                require(_owners[tokenId] == address(0));
+                _owners[tokenId] = to;
+                emit Transfer(address(0), to, tokenId);
+            }
+            _balances[to] += count;
+        }
+    }
+
+    function transferFrom(address from, address to, uint256 tokenId) external {
+        require(msg.sender == from);
+        require(to != address(0));
+        require(_owners[tokenId] == from);
+
+        _owners[tokenId] = to;
+        unchecked {
+            _balances[from] -= 1;
+            _balances[to] += 1;
+        }
+
+        emit Transfer(from, to, tokenId);
+    }
+}
+
+/**
+ * @dev Minimal ERC1155 for benchmarking, with the same simplifications as
+ * {BenchERC721}: no operators and no receiver hooks.
+ */
+contract BenchERC1155 {
+    mapping (uint256 => mapping (address => uint256)) private _balances;
+
+    event TransferSingle(address indexed operator, address indexed from, address indexed to, uint256 id, uint256 value);
+
+    event TransferBatch(address indexed operator, address indexed from, address indexed to, uint256[] ids, uint256[] values);
+
+    function balanceOf(address account, uint256 id) external view returns (uint256) {
+        return _balances[id][account];
+    }
+
+    function mint(address to, uint256 id, uint256 amount) external {
+        require(to != address(0));
+
+        unchecked {
+            _balances[id][to] += amount;
+        }
+
+        emit TransferSingle(msg.sender, address(0), to, id, amount);
+    }
+
+    function safeTransferFrom(address from, address to, uint256 id, uint256 amount, bytes calldata) external {
+        require(msg.sender == from);
+        require(to != address(0));
+        require(_balances[id][from] >= amount);
+
+        unchecked {
+            _balances[id][from] -= amount;
+            _balances[id][to] += amount;
+        }
+
+        emit TransferSingle(msg.sender, from, to, id, amount);
+    }
+
+    function safeBatchTransferFrom(address from, address to, uint256[] calldata ids, uint256[] calldata amounts, bytes calldata) external {
+        require(msg.sender == from);
+        require(to != address(0));
+        require(ids.length == amounts.length);
+
+        for (uint256 i = 0; i < ids.length; i++) {
+            require(_balances[ids[i]][from] >= amounts[i]);
+            unchecked {
+                _balances[ids[i]][from] -= amounts[i];
+                _balances[ids[i]][to] += amounts[i];
+            }
+        }
+
+        emit TransferBatch(msg.sender, from, to, ids, amounts);
+    }
+}
@@ -0,0 +1,3 @@
+; Hand-written assembly of noop.sol, see tools/asmmeta for the syntax.
+
+STOP
@@ -0,0 +1,15 @@
+// SPDX-License-Identifier: MIT
+
+// The readable equivalent of NoOp.asm, which is what the benchmark deploys.
+// This file is not compiled, "make asm-check" runs NoOp.asm through NoOp.abi
+// instead.
+
+pragma solidity ^0.8.0;
+
+/**
+ * @dev Accepts any call and does nothing, so that the cost of calling it is
+ * the calldata alone.
+ */
+contract NoOp {
+    fallback() external payable {}
+}
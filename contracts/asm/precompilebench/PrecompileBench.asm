@@ -0,0 +1,22 @@
+; Hand-written assembly of precompilebench.sol, see tools/asmmeta for the syntax.
+
+0 CALLDATALOAD 0xe0 SHR
+CALLVALUE @revert JUMPI
+DUP1 SEL(run(address,uint256,bytes)) EQ @run JUMPI
+revert:
+0 DUP1 REVERT
+
+run:
+68 CALLDATALOAD 4 ADD
+DUP1 CALLDATALOAD
+DUP1 SWAP2 32 ADD 0 CALLDATACOPY
+; [len, times, i]
+36 CALLDATALOAD 0
+loop:
+DUP2 DUP2 LT ISZERO @done JUMPI
+0 0 DUP5 0 4 CALLDATALOAD GAS STATICCALL
+ISZERO @revert JUMPI
+1 ADD
+@loop JUMP
+done:
+STOP
@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: MIT
+
+// The readable equivalent of PrecompileBench.asm, which is what the benchmark
+// deploys. This file is not compiled, "make asm-check" runs PrecompileBench.asm
+// through PrecompileBench.abi instead.
+
+pragma solidity ^0.8.0;
+
+/**
+ * @dev Calls a precompile repeatedly with the same input, reverting if any of
+ * the calls fails.
+ */
+contract PrecompileBench {
+    function run(address target, uint256 times, bytes calldata input) external view {
+        assembly {
+            // the input sits at offset 0 and the output is not copied back
+            calldatacopy(0, input.offset, input.length)
+            for { let i := 0 } lt(i, times) { i := add(i, 1) } {
+                if iszero(staticcall(gas(), target, 0, input.length, 0, 0)) {
+                    revert(0, 0)
+                }
+            }
+        }
+    }
+}
@@ -0,0 +1,38 @@
+; Hand-written assembly of statebench.sol, see tools/asmmeta for the syntax.
+
+0 CALLDATALOAD 0xe0 SHR
+CALLVALUE @revert JUMPI
+DUP1 SEL(write(uint256,uint256,uint256)) EQ @write JUMPI
+DUP1 SEL(read(uint256,uint256)) EQ @read JUMPI
+DUP1 SEL(clear(uint256,uint256)) EQ @clear JUMPI
+revert:
+0 DUP1 REVERT
+
+write:
+4 CALLDATALOAD DUP1 36 CALLDATALOAD ADD
+writeLoop:
+DUP1 DUP3 LT ISZERO @done JUMPI
+68 CALLDATALOAD DUP3 SSTORE
+SWAP1 1 ADD SWAP1
+@writeLoop JUMP
+
+clear:
+4 CALLDATALOAD DUP1 36 CALLDATALOAD ADD
+clearLoop:
+DUP1 DUP3 LT ISZERO @done JUMPI
+0 DUP3 SSTORE
+SWAP1 1 ADD SWAP1
+@clearLoop JUMP
+
+done:
+STOP
+
+read:
+0 4 CALLDATALOAD DUP1 36 CALLDATALOAD ADD
+readLoop:
+DUP1 DUP3 LT ISZERO @readDone JUMPI
+DUP2 SLOAD DUP4 ADD SWAP3 POP
+SWAP1 1 ADD SWAP1
+@readLoop JUMP
+readDone:
+POP POP 0 MSTORE 32 0 RETURN
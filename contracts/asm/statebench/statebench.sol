@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: MIT
+
+// The readable equivalent of StateBench.asm, which is what the benchmark
+// deploys. This file is not compiled, "make asm-check" runs StateBench.asm
+// through StateBench.abi instead.
+
+pragma solidity ^0.8.0;
+
+/**
+ * @dev Reads, writes and clears ranges of raw storage slots so that the cost
+ * of a transaction is dominated by state access.
+ */
+contract StateBench {
+    function write(uint256 start, uint256 count, uint256 value) external {
+        assembly {
+            for { let i := start } lt(i, add(start, count)) { i := add(i, 1) } {
+                sstore(i, value)
+            }
+        }
+    }
+
+    function clear(uint256 start, uint256 count) external {
+        assembly {
+            for { let i := start } lt(i, add(start, count)) { i := add(i, 1) } {
+                sstore(i, 0)
+            }
+        }
+    }
+
+    function read(uint256 start, uint256 count) external view returns (uint256 sum) {
+        assembly {
+            for { let i := start } lt(i, add(start, count)) { i := add(i, 1) } {
+                sum := add(sum, sload(i))
+            }
+        }
+    }
+}
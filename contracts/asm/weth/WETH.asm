@@ -0,0 +1,37 @@
+; Hand-written assembly of weth.sol, see tools/asmmeta for the syntax.
+
+CALLDATASIZE ISZERO @deposit JUMPI
+0 CALLDATALOAD 0xe0 SHR
+DUP1 SEL(deposit()) EQ @deposit JUMPI
+DUP1 SEL(withdraw(uint256)) EQ @withdraw JUMPI
+DUP1 SEL(balanceOf(address)) EQ @balanceOf JUMPI
+DUP1 SEL(totalSupply()) EQ @totalSupply JUMPI
+revert:
+0 DUP1 REVERT
+
+deposit:
+CALLER 0 MSTORE 0 32 MSTORE 64 0 SHA3
+DUP1 SLOAD CALLVALUE ADD SWAP1 SSTORE
+CALLVALUE 0 MSTORE
+CALLER TOPIC(Deposit(address,uint256)) 32 0 LOG2
+STOP
+
+; [sel, slot, wad]
+withdraw:
+CALLVALUE @revert JUMPI
+CALLER 0 MSTORE 0 32 MSTORE 64 0 SHA3
+4 CALLDATALOAD
+DUP1 DUP3 SLOAD LT @revert JUMPI
+DUP1 DUP3 SLOAD SUB DUP3 SSTORE
+0 0 0 0 DUP5 CALLER 0 CALL ISZERO @revert JUMPI
+0 MSTORE
+CALLER TOPIC(Withdrawal(address,uint256)) 32 0 LOG2
+STOP
+
+balanceOf:
+CALLVALUE @revert JUMPI
+4 CALLDATALOAD 0 MSTORE 0 32 MSTORE 64 0 SHA3 SLOAD 0 MSTORE 32 0 RETURN
+
+totalSupply:
+CALLVALUE @revert JUMPI
+SELFBALANCE 0 MSTORE 32 0 RETURN
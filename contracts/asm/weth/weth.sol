@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: MIT
+
+// The readable equivalent of WETH.asm, which is what the benchmark deploys.
+// This file is not compiled, "make asm-check" runs WETH.asm through WETH.abi
+// instead.
+
+pragma solidity ^0.8.0;
+
+/**
+ * @dev Wrapped native token in the style of WETH9: {deposit} turns the value
+ * sent into tokens and {withdraw} burns tokens to send the value back, so that
+ * every call changes an account balance and the token storage together.
+ */
+contract WETH {
+    mapping (address => uint256) public balanceOf;
+
+    event Deposit(address indexed dst, uint256 wad);
+    event Withdrawal(address indexed src, uint256 wad);
+
+    receive() external payable {
+        deposit();
+    }
+
+    function deposit() public payable {
+        unchecked {
+            balanceOf[msg.sender] += msg.value;
+        }
+        emit Deposit(msg.sender, msg.value);
+    }
+
+    function withdraw(uint256 wad) external {
+        require(balanceOf[msg.sender] >= wad);
+        balanceOf[msg.sender] -= wad;
+        payable(msg.sender).transfer(wad);
+        emit Withdrawal(msg.sender, wad);
+    }
+
+    function totalSupply() external view returns (uint256) {
+        return address(this).balance;
+    }
+}
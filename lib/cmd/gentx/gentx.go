@@ -4,25 +4,32 @@ import (
 	"log"
 
 	generatorpkg "github.com/0glabs/evmchainbench/lib/generator"
+	"github.com/0glabs/evmchainbench/lib/store"
 )
 
-func GenTx(rpcUrl, faucetPrivateKey string, senderCount, txCount int, txType string, txStoreDir string) {
-	generator, err := generatorpkg.NewGenerator(rpcUrl, faucetPrivateKey, senderCount, txCount, true, txStoreDir)
+func GenTx(rpcUrl, faucetPrivateKey string, senderCount, txCount int, txType string, params generatorpkg.Params, txEnvelope, fee, tipTiers, accessList, txStoreDir string) {
+	workload, err := generatorpkg.NewWorkload(txType, params)
 	if err != nil {
-		log.Fatalf("Failed to create generator: %v", err)
+		log.Fatalf("Failed to create workload: %v", err)
 	}
 
-	switch txType {
-	case "simple":
-		_, err = generator.GenerateSimple()
-	case "erc20":
-		_, err = generator.GenerateERC20()
-	case "uniswap":
-		_, err = generator.GenerateUniswap()
-	default:
-		log.Fatalf("Transaction type \"%v\" is not valid", txType)
+	generator, err := generatorpkg.NewGenerator(rpcUrl, faucetPrivateKey, senderCount, txCount, txEnvelope, fee, true, txStoreDir)
+	if err != nil {
+		log.Fatalf("Failed to create generator: %v", err)
 	}
+	generator.AccessList = accessList
+	generator.TipTiers, err = generatorpkg.ParseTipTiers(tipTiers)
+	if err != nil {
+		log.Fatalf("Failed to parse tip tiers: %v", err)
+	}
+
+	_, err = generator.Generate(workload)
 	if err != nil {
 		log.Fatalf("Failed to generate transactions: %v", err)
 	}
+
+	err = generator.Store.PersistWorkload(store.WorkloadInfo{Name: txType, Params: params})
+	if err != nil {
+		log.Fatalf("Failed to persist workload: %v", err)
+	}
 }
@@ -2,9 +2,11 @@ package load
 
 import (
 	"context"
+	"log"
 	"time"
 
 	"github.com/0glabs/evmchainbench/lib/cmd/run"
+	generatorpkg "github.com/0glabs/evmchainbench/lib/generator"
 	"github.com/0glabs/evmchainbench/lib/store"
 	"github.com/0glabs/evmchainbench/lib/util"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -23,6 +25,18 @@ func NewLoader(rpcUrl, txStoreDir string) *Loader {
 }
 
 func (l *Loader) LoadAndRun() error {
+	info, err := l.Store.LoadWorkload()
+	if err != nil {
+		return err
+	}
+	if info != nil {
+		workload, err := generatorpkg.NewWorkload(info.Name, info.Params)
+		if err != nil {
+			return err
+		}
+		log.Default().Println("Loading", workload.Name(), "workload:", workload.Describe())
+	}
+
 	client, err := ethclient.Dial(l.RpcUrl)
 	if err != nil {
 		return err
@@ -4,18 +4,37 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"math/big"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	limiterpkg "github.com/0glabs/evmchainbench/lib/limiter"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/gorilla/websocket"
 )
 
+// The ids of eth_getLogs and eth_getBlockReceipts requests name their block.
+// The blocks which came back short of logs are asked for again, to tell lag
+// from loss.
+const (
+	logsRequestPrefix     = "logs-"
+	recheckRequestPrefix  = "recheck-"
+	receiptsRequestPrefix = "receipts-"
+)
+
 type BlockInfo struct {
 	Time     int64
 	TxCount  int64
 	GasUsed  int64
 	GasLimit int64
+	// Size is the block size in bytes
+	Size int64
 }
 
 type EthereumListener struct {
@@ -26,6 +45,56 @@ type EthereumListener struct {
 	quit             chan struct{}
 	bestTPS          int64
 	gasUsedAtBestTPS float64
+	mgasAtBestTPS    float64
+	kbAtBestTPS      float64
+
+	// txLabels maps lower-case tx hashes to the kind reported in the summary
+	labelMutex    sync.Mutex
+	txLabels      map[string]string
+	labelIncluded map[string]int64
+	// block times of the first and last inclusion of every kind
+	labelFirst map[string]int64
+	labelLast  map[string]int64
+	// the receipts of every kind, checked when some kinds must fail
+	expectFailure func(label string) bool
+	labelStatus   map[string]*receiptStatus
+
+	// blob gas of the blocks seen, on chains with EIP-4844
+	blobGasUsed int64
+	blobBlocks  int64
+
+	baseFeeHandler func(baseFee, blobBaseFee *big.Int)
+
+	// the logs expected and returned by eth_getLogs per block, checked when
+	// every successful tx of the workload, in logTxs, emits logsPerTx logs
+	logsPerTx    int
+	logTxs       map[string]bool
+	expectedLogs map[string]int
+	returnedLogs map[string]int
+	recheck      []string
+	logStats     struct {
+		blocks, expected, returned int64
+		short, caughtUp, lost      int64
+	}
+
+	// txTiers maps lower-case tx hashes to their tip tier, sentAt to the time
+	// the transmitter sent them
+	tierMutex    sync.Mutex
+	txTiers      map[string]string
+	sentAt       map[string]time.Time
+	tierSent     map[string]int64
+	tierIncluded map[string]int64
+	tierLatency  map[string][]time.Duration
+	// inclusions in the blocks built while every tier had txs waiting
+	tierContested map[string]int64
+}
+
+// receiptStatus counts the receipts of a kind of transactions.
+type receiptStatus struct {
+	succeeded, failed int64
+	// receipts whose status is not the expected one
+	unexpected              int64
+	gasSucceeded, gasFailed int64
 }
 
 func NewEthereumListener(wsURL string, limiter *limiterpkg.RateLimiter) *EthereumListener {
@@ -36,6 +105,88 @@ func NewEthereumListener(wsURL string, limiter *limiterpkg.RateLimiter) *Ethereu
 	}
 }
 
+// SetTxLabels makes the listener count included transactions per kind.
+func (el *EthereumListener) SetTxLabels(labels map[common.Hash]string) {
+	el.txLabels = make(map[string]string, len(labels))
+	for hash, label := range labels {
+		el.txLabels[strings.ToLower(hash.Hex())] = label
+	}
+	el.labelIncluded = make(map[string]int64)
+	el.labelFirst = make(map[string]int64)
+	el.labelLast = make(map[string]int64)
+}
+
+// SetExpectFailure makes the listener check the status of the receipt of
+// every labelled transaction, expectFailure tells which kinds must fail.
+func (el *EthereumListener) SetExpectFailure(expectFailure func(label string) bool) {
+	el.expectFailure = expectFailure
+	el.labelStatus = make(map[string]*receiptStatus)
+}
+
+// SetLogsPerTx makes the listener check that eth_getLogs returns logsPerTx
+// logs per successful tx of the workload, one of hashes, in every block.
+func (el *EthereumListener) SetLogsPerTx(logsPerTx int, hashes []common.Hash) {
+	el.logsPerTx = logsPerTx
+	el.logTxs = make(map[string]bool, len(hashes))
+	for _, hash := range hashes {
+		el.logTxs[strings.ToLower(hash.Hex())] = true
+	}
+	el.expectedLogs = make(map[string]int)
+	el.returnedLogs = make(map[string]int)
+}
+
+// SetTxTiers makes the listener report the inclusion latency and the share
+// of the included transactions of every tip tier.
+func (el *EthereumListener) SetTxTiers(tiers map[common.Hash]string) {
+	el.txTiers = make(map[string]string, len(tiers))
+	for hash, tier := range tiers {
+		el.txTiers[strings.ToLower(hash.Hex())] = tier
+	}
+	el.sentAt = make(map[string]time.Time, len(tiers))
+	el.tierSent = make(map[string]int64)
+	el.tierIncluded = make(map[string]int64)
+	el.tierLatency = make(map[string][]time.Duration)
+	el.tierContested = make(map[string]int64)
+}
+
+// TxSent records when a tx was sent. It is safe for concurrent use.
+func (el *EthereumListener) TxSent(hash common.Hash) {
+	el.tierMutex.Lock()
+	defer el.tierMutex.Unlock()
+	if el.sentAt != nil {
+		el.sentAt[strings.ToLower(hash.Hex())] = time.Now()
+		el.tierSent[el.txTiers[strings.ToLower(hash.Hex())]]++
+	}
+}
+
+// SetBaseFeeHandler makes the listener report the base fee and the blob base
+// fee of every block.
+func (el *EthereumListener) SetBaseFeeHandler(handler func(baseFee, blobBaseFee *big.Int)) {
+	el.baseFeeHandler = handler
+}
+
+// ReplaceTx carries the kind and the tier of a tx over to the tx replacing
+// it. It is safe for concurrent use.
+func (el *EthereumListener) ReplaceTx(from, to common.Hash) {
+	el.labelMutex.Lock()
+	if label, ok := el.txLabels[strings.ToLower(from.Hex())]; ok {
+		el.txLabels[strings.ToLower(to.Hex())] = label
+		delete(el.txLabels, strings.ToLower(from.Hex()))
+	}
+	if el.logTxs[strings.ToLower(from.Hex())] {
+		el.logTxs[strings.ToLower(to.Hex())] = true
+		delete(el.logTxs, strings.ToLower(from.Hex()))
+	}
+	el.labelMutex.Unlock()
+
+	el.tierMutex.Lock()
+	if tier, ok := el.txTiers[strings.ToLower(from.Hex())]; ok {
+		el.txTiers[strings.ToLower(to.Hex())] = tier
+		delete(el.txTiers, strings.ToLower(from.Hex()))
+	}
+	el.tierMutex.Unlock()
+}
+
 func (el *EthereumListener) Connect() error {
 	conn, _, err := websocket.DefaultDialer.Dial(el.wsURL, http.Header{})
 	if err != nil {
@@ -78,6 +229,12 @@ func (el *EthereumListener) listenForMessages() {
 
 		if method, ok := response["method"]; ok && method == "eth_subscription" {
 			el.handleNewHead(response)
+		} else if id, ok := response["id"].(string); ok {
+			if block, ok := strings.CutPrefix(id, receiptsRequestPrefix); ok {
+				el.handleReceipts(block, response)
+			} else {
+				el.handleLogs(id, response)
+			}
 		} else {
 			el.handleBlockResponse(response)
 		}
@@ -108,9 +265,30 @@ func (el *EthereumListener) handleNewHead(response map[string]interface{}) {
 		log.Println("Failed to send block request:", err)
 	}
 
-	request = map[string]interface{}{
+	el.requestLogs(logsRequestPrefix, blockNo)
+	for _, block := range el.recheck {
+		el.requestLogs(recheckRequestPrefix, block)
+	}
+	el.recheck = nil
+
+	if el.expectFailure != nil || el.logsPerTx > 0 {
+		request = map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      receiptsRequestPrefix + blockNo,
+			"method":  "eth_getBlockReceipts",
+			"params":  []interface{}{blockNo},
+		}
+		err = el.conn.WriteJSON(request)
+		if err != nil {
+			log.Println("Failed to send receipts request:", err)
+		}
+	}
+}
+
+func (el *EthereumListener) requestLogs(prefix, blockNo string) {
+	request := map[string]interface{}{
 		"jsonrpc": "2.0",
-		"id":      1,
+		"id":      prefix + blockNo,
 		"method":  "eth_getLogs",
 		"params": []interface{}{
 			map[string]interface{}{
@@ -119,25 +297,158 @@ func (el *EthereumListener) handleNewHead(response map[string]interface{}) {
 			},
 		},
 	}
-	err = el.conn.WriteJSON(request)
+	err := el.conn.WriteJSON(request)
 	if err != nil {
 		log.Println("Failed to send log request:", err)
 	}
 }
 
+func (el *EthereumListener) handleLogs(id string, response map[string]interface{}) {
+	logs, ok := response["result"].([]interface{})
+	if !ok {
+		log.Println("Failed to get logs:", response["error"])
+		return
+	}
+
+	if block, ok := strings.CutPrefix(id, recheckRequestPrefix); ok {
+		if len(logs) == el.expectedLogs[block] {
+			el.logStats.caughtUp++
+		} else {
+			el.logStats.lost++
+			log.Printf("Logs of block %s: expected %d, eth_getLogs returned %d again", block, el.expectedLogs[block], len(logs))
+		}
+		delete(el.expectedLogs, block)
+		return
+	}
+
+	if len(logs) > 0 {
+		fmt.Println("Logs:", len(logs))
+	}
+	if el.logsPerTx > 0 {
+		block := strings.TrimPrefix(id, logsRequestPrefix)
+		el.returnedLogs[block] = len(logs)
+		el.checkLogs(block)
+	}
+}
+
+// checkLogs compares the logs of a block once both the block and its logs
+// have come back.
+func (el *EthereumListener) checkLogs(block string) {
+	expected, ok := el.expectedLogs[block]
+	if !ok {
+		return
+	}
+	returned, ok := el.returnedLogs[block]
+	if !ok {
+		return
+	}
+	delete(el.returnedLogs, block)
+
+	el.logStats.blocks++
+	el.logStats.expected += int64(expected)
+	el.logStats.returned += int64(returned)
+	if returned != expected {
+		el.logStats.short++
+		log.Printf("Logs of block %s: expected %d, eth_getLogs returned %d", block, expected, returned)
+		el.recheck = append(el.recheck, block)
+		return
+	}
+	delete(el.expectedLogs, block)
+}
+
+func (el *EthereumListener) handleReceipts(block string, response map[string]interface{}) {
+	receipts, ok := response["result"].([]interface{})
+	if !ok {
+		log.Println("Failed to get block receipts:", response["error"])
+		return
+	}
+
+	el.labelMutex.Lock()
+	defer el.labelMutex.Unlock()
+	// only the successful txs of the workload emit logs, not the txs of
+	// others or the invalid ones
+	logTxs := 0
+	for _, r := range receipts {
+		receipt, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		hash, _ := receipt["transactionHash"].(string)
+		status, _ := receipt["status"].(string)
+		if el.logTxs[strings.ToLower(hash)] && status == "0x1" {
+			logTxs++
+		}
+		if el.expectFailure == nil {
+			continue
+		}
+		label, ok := el.txLabels[strings.ToLower(hash)]
+		if !ok {
+			continue
+		}
+		gas, _ := receipt["gasUsed"].(string)
+		gasUsed, _ := strconv.ParseInt(strings.TrimPrefix(gas, "0x"), 16, 64)
+
+		s, ok := el.labelStatus[label]
+		if !ok {
+			s = &receiptStatus{}
+			el.labelStatus[label] = s
+		}
+		failed := status == "0x0"
+		if failed {
+			s.failed++
+			s.gasFailed += gasUsed
+		} else {
+			s.succeeded++
+			s.gasSucceeded += gasUsed
+		}
+		if failed != el.expectFailure(label) {
+			s.unexpected++
+		}
+	}
+
+	if el.logsPerTx > 0 {
+		el.expectedLogs[block] = logTxs * el.logsPerTx
+		el.checkLogs(block)
+	}
+}
+
 func (el *EthereumListener) handleBlockResponse(response map[string]interface{}) {
 	if result, ok := response["result"].(map[string]interface{}); ok {
 		if txns, ok := result["transactions"].([]interface{}); ok {
 			el.limiter.IncreaseLimit(len(txns))
 			ts, _ := strconv.ParseInt(result["timestamp"].(string)[2:], 16, 64)
+			el.countLabels(txns, ts)
+			el.countTiers(txns, time.Now())
+			if baseFee, ok := result["baseFeePerGas"].(string); ok && el.baseFeeHandler != nil {
+				if fee, ok := new(big.Int).SetString(baseFee[2:], 16); ok {
+					// nil before Cancun
+					var blobFee *big.Int
+					if excess, ok := result["excessBlobGas"].(string); ok {
+						if excessBlobGas, err := strconv.ParseUint(excess[2:], 16, 64); err == nil {
+							blobFee = eip4844.CalcBlobFee(excessBlobGas)
+						}
+					}
+					el.baseFeeHandler(fee, blobFee)
+				}
+			}
 			gasUsed, _ := strconv.ParseInt(result["gasUsed"].(string)[2:], 16, 64)
 			gasLimit, _ := strconv.ParseInt(result["gasLimit"].(string)[2:], 16, 64)
-			log.Default().Println("TxCount:", len(txns), "GasUsed:", gasUsed, "GasLimit:", gasLimit)
+			blockSize, _ := result["size"].(string)
+			size, _ := strconv.ParseInt(strings.TrimPrefix(blockSize, "0x"), 16, 64)
+			if blobGas, ok := result["blobGasUsed"].(string); ok {
+				blobGasUsed, _ := strconv.ParseInt(blobGas[2:], 16, 64)
+				el.blobGasUsed += blobGasUsed
+				el.blobBlocks++
+				log.Default().Println("TxCount:", len(txns), "GasUsed:", gasUsed, "GasLimit:", gasLimit, "BlobGasUsed:", blobGasUsed)
+			} else {
+				log.Default().Println("TxCount:", len(txns), "GasUsed:", gasUsed, "GasLimit:", gasLimit)
+			}
 			el.blockStat = append(el.blockStat, BlockInfo{
 				Time:     ts,
 				TxCount:  int64(len(txns)),
 				GasUsed:  gasUsed,
 				GasLimit: gasLimit,
+				Size:     size,
 			})
 			// keep only the last 60 seconds of blocks
 			for {
@@ -184,22 +495,29 @@ func (el *EthereumListener) handleBlockResponse(response map[string]interface{})
 						totalTxCount := int64(0)
 						totalGasLimit := int64(0)
 						totalGasUsed := int64(0)
+						totalSize := int64(0)
 						for i := startIdx; i <= endIdx; i++ {
 							totalTxCount += el.blockStat[i].TxCount
 							totalGasLimit += el.blockStat[i].GasLimit
 							totalGasUsed += el.blockStat[i].GasUsed
+							totalSize += el.blockStat[i].Size
 						}
 						tps := totalTxCount / timeSpan
 						log.Default().Println("TimeSpan:", timeSpan, "TotalTxCount:", totalTxCount)
 						gasUsedPercent := float64(totalGasUsed) / float64(totalGasLimit)
+						mgasPerSecond := float64(totalGasUsed) / float64(timeSpan) / 1e6
+						// bytes of the included blocks
+						kbPerSecond := float64(totalSize) / float64(timeSpan) / 1024
 						if tps > el.bestTPS {
 							el.bestTPS = tps
 							el.gasUsedAtBestTPS = gasUsedPercent
+							el.mgasAtBestTPS = mgasPerSecond
+							el.kbAtBestTPS = kbPerSecond
 						}
-						fmt.Printf("TPS: %d GasUsed%%: %.2f%%\n", tps, gasUsedPercent*100)
+						fmt.Printf("TPS: %d GasUsed%%: %.2f%% MGas/s: %.2f KB/s: %.2f\n", tps, gasUsedPercent*100, mgasPerSecond, kbPerSecond)
 						if totalTxCount < 100 {
 							// exit if total tx count is less than 100
-							fmt.Printf("Best TPS: %d GasUsed%%: %.2f%%\n", el.bestTPS, el.gasUsedAtBestTPS*100)
+							el.printSummary()
 							el.Close()
 							return
 						}
@@ -214,21 +532,199 @@ func (el *EthereumListener) handleBlockResponse(response map[string]interface{})
 								}
 							}
 							if emptyTail {
-								fmt.Printf("Best TPS: %d GasUsed%%: %.2f%%\n", el.bestTPS, el.gasUsedAtBestTPS*100)
+								el.printSummary()
 								el.Close()
 							}
 						}
 					}
 				}
 			}
-		} else {
-			if result, ok := response["result"].([]interface{}); ok {
-				if len(result) > 0 {
-					fmt.Println("Logs:", len(result))
-				}
+		}
+	}
+}
+
+func (el *EthereumListener) countLabels(txns []interface{}, ts int64) {
+	el.labelMutex.Lock()
+	defer el.labelMutex.Unlock()
+	if el.txLabels == nil {
+		return
+	}
+	for _, txn := range txns {
+		hash, ok := txn.(string)
+		if !ok {
+			continue
+		}
+		if label, ok := el.txLabels[strings.ToLower(hash)]; ok {
+			if el.labelIncluded[label] == 0 {
+				el.labelFirst[label] = ts
+			}
+			el.labelIncluded[label]++
+			el.labelLast[label] = ts
+		}
+	}
+}
+
+func (el *EthereumListener) countTiers(txns []interface{}, now time.Time) {
+	el.tierMutex.Lock()
+	defer el.tierMutex.Unlock()
+	if el.txTiers == nil {
+		return
+	}
+
+	// whether the block was built with txs of every tier to choose from
+	contested := len(el.tierSent) > 1
+	for tier, sent := range el.tierSent {
+		if sent <= el.tierIncluded[tier] {
+			contested = false
+		}
+	}
+
+	for _, txn := range txns {
+		hash, ok := txn.(string)
+		if !ok {
+			continue
+		}
+		hash = strings.ToLower(hash)
+		if tier, ok := el.txTiers[hash]; ok {
+			el.tierIncluded[tier]++
+			if contested {
+				el.tierContested[tier]++
 			}
+			if sent, ok := el.sentAt[hash]; ok {
+				el.tierLatency[tier] = append(el.tierLatency[tier], now.Sub(sent))
+			}
+		}
+	}
+}
+
+func (el *EthereumListener) printSummary() {
+	fmt.Printf("Best TPS: %d GasUsed%%: %.2f%% MGas/s: %.2f KB/s: %.2f\n", el.bestTPS, el.gasUsedAtBestTPS*100, el.mgasAtBestTPS, el.kbAtBestTPS)
+	el.printTierSummary()
+	if el.logsPerTx > 0 {
+		s := el.logStats
+		fmt.Printf("Logs checked: expected %d, eth_getLogs returned %d in %d blocks, %d blocks short: %d caught up when asked again, %d lost\n",
+			s.expected, s.returned, s.blocks, s.short, s.caughtUp, s.lost)
+	}
+	if el.blobGasUsed > 0 {
+		fmt.Printf("Blob gas used: %d in %d blocks, %.2f blobs per block\n", el.blobGasUsed, el.blobBlocks,
+			float64(el.blobGasUsed)/float64(params.BlobTxBlobGasPerBlob)/float64(el.blobBlocks))
+	}
+
+	el.labelMutex.Lock()
+	defer el.labelMutex.Unlock()
+	if el.txLabels == nil {
+		return
+	}
+	generated := make(map[string]int64)
+	for _, label := range el.txLabels {
+		generated[label]++
+	}
+	labels := make([]string, 0, len(generated))
+	for label := range generated {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	for _, label := range labels {
+		fmt.Printf("  %s: generated %d included %d", label, generated[label], el.labelIncluded[label])
+		// throughput of the kind over the blocks it was included in
+		if timeSpan := el.labelLast[label] - el.labelFirst[label]; timeSpan > 0 {
+			fmt.Printf(" TPS: %.2f", float64(el.labelIncluded[label])/float64(timeSpan))
+		}
+		fmt.Println()
+	}
+	el.printStatusSummary(labels)
+}
+
+// printStatusSummary expects the labelMutex to be held.
+func (el *EthereumListener) printStatusSummary(labels []string) {
+	if el.labelStatus == nil {
+		return
+	}
+
+	fmt.Println("Receipt statuses:")
+	var gasSucceeded, gasFailed, unexpected, checked, included int64
+	for _, label := range labels {
+		included += el.labelIncluded[label]
+		s, ok := el.labelStatus[label]
+		if !ok {
+			continue
 		}
+		fmt.Printf("  %s: succeeded %d failed %d gas per tx %d\n", label, s.succeeded, s.failed,
+			(s.gasSucceeded+s.gasFailed)/(s.succeeded+s.failed))
+		gasSucceeded += s.gasSucceeded
+		gasFailed += s.gasFailed
+		unexpected += s.unexpected
+		checked += s.succeeded + s.failed
+	}
+	if gasSucceeded+gasFailed > 0 {
+		fmt.Printf("Failed txs used %.2f%% of the gas of the labelled txs\n", float64(gasFailed)/float64(gasSucceeded+gasFailed)*100)
+	}
+	switch {
+	case unexpected > 0:
+		fmt.Printf("Unexpected statuses: %d\n", unexpected)
+	case checked == 0:
+		// e.g. the node does not serve eth_getBlockReceipts
+		fmt.Printf("No receipts checked for the %d included labelled txs, their statuses are unknown\n", included)
+	case checked < included:
+		fmt.Printf("Checked the receipts of %d of %d included labelled txs, the statuses of the rest are unknown\n", checked, included)
+	default:
+		fmt.Println("All statuses as expected")
+	}
+}
+
+func (el *EthereumListener) printTierSummary() {
+	el.tierMutex.Lock()
+	defer el.tierMutex.Unlock()
+	if el.txTiers == nil {
+		return
+	}
+
+	generated := make(map[string]int64)
+	for _, tier := range el.txTiers {
+		generated[tier]++
+	}
+	included, contested := int64(0), int64(0)
+	for tier, n := range el.tierIncluded {
+		included += n
+		contested += el.tierContested[tier]
+	}
+	tiers := make([]string, 0, len(generated))
+	for tier := range generated {
+		tiers = append(tiers, tier)
+	}
+	sort.Slice(tiers, func(i, j int) bool { return tierTip(tiers[i]) < tierTip(tiers[j]) })
+	for _, tier := range tiers {
+		fmt.Printf("  %s: generated %d included %d", tier, generated[tier], el.tierIncluded[tier])
+		if included > 0 {
+			fmt.Printf(" share %.2f%%", float64(el.tierIncluded[tier])/float64(included)*100)
+		}
+		if contested > 0 {
+			fmt.Printf(" (%.2f%% while all tiers waited)", float64(el.tierContested[tier])/float64(contested)*100)
+		}
+		// from sending to the arrival of the including block
+		if latency := el.tierLatency[tier]; len(latency) > 0 {
+			sort.Slice(latency, func(i, j int) bool { return latency[i] < latency[j] })
+			total := time.Duration(0)
+			for _, l := range latency {
+				total += l
+			}
+			fmt.Printf(" latency avg %.2fs p50 %.2fs p90 %.2fs", (total / time.Duration(len(latency))).Seconds(),
+				latency[len(latency)/2].Seconds(), latency[len(latency)*9/10].Seconds())
+		}
+		fmt.Println()
+	}
+}
+
+// tierTip returns the lowest tip of a tier named "tip 2 gwei" or
+// "tip 1-3.25 gwei".
+func tierTip(tier string) float64 {
+	fields := strings.Fields(tier)
+	if len(fields) < 2 {
+		return 0
 	}
+	low, _, _ := strings.Cut(fields[1], "-")
+	tip, _ := strconv.ParseFloat(low, 64)
+	return tip
 }
 
 func (el *EthereumListener) Close() {
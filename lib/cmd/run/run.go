@@ -1,31 +1,67 @@
 package run
 
 import (
+	"fmt"
 	"log"
 
-	"github.com/0glabs/evmchainbench/lib/generator"
+	"github.com/ethereum/go-ethereum/common"
+
+	generatorpkg "github.com/0glabs/evmchainbench/lib/generator"
 	limiterpkg "github.com/0glabs/evmchainbench/lib/limiter"
-	"github.com/ethereum/go-ethereum/core/types"
 )
 
-func Run(httpRpc, wsRpc, faucetPrivateKey string, senderCount, txCount int, txType string, mempool int, clientPoolSize int) {
-	generator, err := generator.NewGenerator(httpRpc, faucetPrivateKey, senderCount, txCount, false, "")
-	if err != nil {
-		log.Fatalf("Failed to create generator: %v", err)
+func Run(httpRpc, wsRpc, faucetPrivateKey string, senderCount, txCount int, txType string, params generatorpkg.Params, txEnvelope, fee, tipTiers, accessList string, accessListAB bool, invalidRatio float64, invalidKinds string, mempool int, clientPoolSize, batchSize int) {
+	if !accessListAB {
+		runOnce(httpRpc, wsRpc, faucetPrivateKey, senderCount, txCount, txType, params, txEnvelope, fee, tipTiers, accessList, invalidRatio, invalidKinds, mempool, clientPoolSize, batchSize)
+		return
+	}
+
+	if accessList == "" || accessList == generatorpkg.AccessListNone {
+		log.Fatalf("The A/B mode needs an access list source, set --access-list to %s or %s", generatorpkg.AccessListRPC, generatorpkg.AccessListLocal)
+	}
+
+	// the same workload once without and once with access lists, each with fresh senders
+	log.Default().Println("A/B run without access lists...")
+	without := runOnce(httpRpc, wsRpc, faucetPrivateKey, senderCount, txCount, txType, params, txEnvelope, fee, tipTiers, generatorpkg.AccessListNone, invalidRatio, invalidKinds, mempool, clientPoolSize, batchSize)
+	log.Default().Printf("A/B run with %s access lists...", accessList)
+	with := runOnce(httpRpc, wsRpc, faucetPrivateKey, senderCount, txCount, txType, params, txEnvelope, fee, tipTiers, accessList, invalidRatio, invalidKinds, mempool, clientPoolSize, batchSize)
+
+	fmt.Printf("A/B Best TPS: without access lists %d, with access lists %d", without, with)
+	if without > 0 {
+		fmt.Printf(", difference %+.2f%%", float64(with-without)/float64(without)*100)
 	}
+	fmt.Println()
+}
 
-	var txsMap map[int]types.Transactions
+// runOnce generates and broadcasts the transactions of the workload and
+// returns the best TPS seen by the listener.
+func runOnce(httpRpc, wsRpc, faucetPrivateKey string, senderCount, txCount int, txType string, params generatorpkg.Params, txEnvelope, fee, tipTiers, accessList string, invalidRatio float64, invalidKinds string, mempool int, clientPoolSize, batchSize int) int64 {
+	workload, err := generatorpkg.NewWorkload(txType, params)
+	if err != nil {
+		log.Fatalf("Failed to create workload: %v", err)
+	}
 
-	switch txType {
-	case "simple":
-		txsMap, err = generator.GenerateSimple()
-	case "erc20":
-		txsMap, err = generator.GenerateERC20()
-	case "uniswap":
-		txsMap, err = generator.GenerateUniswap()
-	default:
-		log.Fatalf("Transaction type \"%v\" is not valid", txType)
+	generator, err := generatorpkg.NewGenerator(httpRpc, faucetPrivateKey, senderCount, txCount, txEnvelope, fee, false, "")
+	if err != nil {
+		log.Fatalf("Failed to create generator: %v", err)
 	}
+	generator.AccessList = accessList
+	generator.TipTiers, err = generatorpkg.ParseTipTiers(tipTiers)
+	if err != nil {
+		log.Fatalf("Failed to parse tip tiers: %v", err)
+	}
+	if generator.TipTiers != nil {
+		fmt.Println("Tip tiers:", generator.TipTiers)
+	}
+	generator.Invalid, err = generatorpkg.ParseInvalidMix(invalidRatio, invalidKinds)
+	if err != nil {
+		log.Fatalf("Failed to parse invalid txs: %v", err)
+	}
+	if generator.Invalid != nil {
+		fmt.Println("Invalid txs:", generator.Invalid)
+	}
+
+	txsMap, err := generator.Generate(workload)
 	if err != nil {
 		log.Fatalf("Failed to generate transactions: %v", err)
 	}
@@ -33,6 +69,33 @@ func Run(httpRpc, wsRpc, faucetPrivateKey string, senderCount, txCount int, txTy
 	limiter := limiterpkg.NewRateLimiter(mempool)
 
 	ethListener := NewEthereumListener(wsRpc, limiter)
+	if labeler, ok := workload.(generatorpkg.Labeler); ok {
+		ethListener.SetTxLabels(labeler.Labels())
+		if expecter, ok := workload.(generatorpkg.FailureExpecter); ok {
+			ethListener.SetExpectFailure(expecter.ExpectFailure)
+		}
+	}
+	if emitter, ok := workload.(generatorpkg.LogEmitter); ok {
+		// the invalid txs emit no logs even when included
+		var hashes []common.Hash
+		for _, txs := range txsMap {
+			for _, tx := range txs {
+				if _, ok := generator.InvalidTxs[tx.Hash()]; !ok {
+					hashes = append(hashes, tx.Hash())
+				}
+			}
+		}
+		ethListener.SetLogsPerTx(emitter.LogsPerTx(), hashes)
+	}
+	if generator.TxTiers != nil {
+		ethListener.SetTxTiers(generator.TxTiers)
+	}
+	// txs priced by tracking the base fee are signed again while being sent
+	repricer := generator.NewRepricer()
+	if repricer != nil {
+		ethListener.SetBaseFeeHandler(repricer.SetBaseFee)
+		repricer.OnReplace = ethListener.ReplaceTx
+	}
 	err = ethListener.Connect()
 	if err != nil {
 		log.Fatalf("Failed to connect to WebSocket: %v", err)
@@ -48,6 +111,14 @@ func Run(httpRpc, wsRpc, faucetPrivateKey string, senderCount, txCount int, txTy
 	if err != nil {
 		log.Fatalf("Failed to create transmitter: %v", err)
 	}
+	transmitter.SetRepricer(repricer)
+	transmitter.SetBatchSize(batchSize)
+	if generator.TxTiers != nil {
+		transmitter.SetSentHandler(ethListener.TxSent)
+	}
+	if generator.InvalidTxs != nil {
+		transmitter.SetInvalidTxs(generator.InvalidTxs)
+	}
 
 	log.Default().Println("Broadcasting transactions...")
 	err = transmitter.Broadcast(txsMap)
@@ -56,4 +127,6 @@ func Run(httpRpc, wsRpc, faucetPrivateKey string, senderCount, txCount int, txTy
 	}
 
 	<-ethListener.quit
+
+	return ethListener.bestTPS
 }
@@ -4,19 +4,41 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"math"
+	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 
+	generatorpkg "github.com/0glabs/evmchainbench/lib/generator"
 	limiterpkg "github.com/0glabs/evmchainbench/lib/limiter"
 )
 
 type Transmitter struct {
-	RpcUrl  string
-	limiter *limiterpkg.RateLimiter
+	RpcUrl   string
+	limiter  *limiterpkg.RateLimiter
+	repricer *generatorpkg.Repricer
+	onSent   func(hash common.Hash)
+
+	// the txs sent per JSON-RPC batch request to the endpoint
+	batchSize int
+
+	// invalid maps the txs meant to be rejected to their kind, rejections
+	// counts the errors of the node by kind and reason
+	invalid     map[common.Hash]string
+	rejectMutex sync.Mutex
+	rejections  map[string]map[string]int
+
+	// the txs accepted by the node and their encoded size
+	sentTxs   int64
+	sentBytes int64
 
 	pool      []*ethclient.Client
 	poolOnce  sync.Once
@@ -27,12 +49,40 @@ type Transmitter struct {
 
 func NewTransmitter(rpcUrl string, limiter *limiterpkg.RateLimiter, poolSize int) (*Transmitter, error) {
 	return &Transmitter{
-		RpcUrl:   rpcUrl,
-		limiter:  limiter,
-		poolSize: poolSize,
+		RpcUrl:    rpcUrl,
+		limiter:   limiter,
+		poolSize:  poolSize,
+		batchSize: 1,
 	}, nil
 }
 
+// SetBatchSize makes the transmitter send the txs of up to batchSize senders
+// in JSON-RPC batch requests, one tx per sender in each request so that the
+// nonces of every sender keep their order.
+func (t *Transmitter) SetBatchSize(batchSize int) {
+	t.batchSize = max(batchSize, 1)
+}
+
+// SetRepricer makes the transmitter price every tx again right before
+// sending it, nil keeps the generated prices.
+func (t *Transmitter) SetRepricer(repricer *generatorpkg.Repricer) {
+	t.repricer = repricer
+}
+
+// SetSentHandler makes the transmitter report every tx right before sending
+// it.
+func (t *Transmitter) SetSentHandler(handler func(hash common.Hash)) {
+	t.onSent = handler
+}
+
+// SetInvalidTxs makes the transmitter send the given txs once, outside of
+// the mempool limit, and count why the node rejected them instead of
+// reporting them as failures.
+func (t *Transmitter) SetInvalidTxs(invalid map[common.Hash]string) {
+	t.invalid = invalid
+	t.rejections = make(map[string]map[string]int)
+}
+
 func (t *Transmitter) getClientFromPool() (*ethclient.Client, error) {
 	t.poolOnce.Do(func() {
 		ps := t.poolSize
@@ -70,73 +120,376 @@ func (t *Transmitter) getClientFromPool() (*ethclient.Client, error) {
 }
 
 func (t *Transmitter) Broadcast(txsMap map[int]types.Transactions) error {
-	ch := make(chan error)
-
 	// Ensure pool initialized early to catch any fatal errors
 	if _, err := t.getClientFromPool(); err != nil {
 		return fmt.Errorf("failed to initialize RPC client pool: %w", err)
 	}
 
-	for _, txs := range txsMap {
-		go func(txs []*types.Transaction) {
+	start := time.Now()
+	// every batcher sends the txs of up to batchSize senders
+	batchers := make([]*batcher, (len(txsMap)+t.batchSize-1)/t.batchSize)
+	var batcherWait sync.WaitGroup
+	for i := range batchers {
+		batchers[i] = &batcher{
+			t:       t,
+			queue:   make(chan *batchItem, t.batchSize),
+			pending: make(map[int][]*batchItem),
+			sent:    make(map[int]map[uint64]bool),
+		}
+		batcherWait.Add(1)
+		go func(b *batcher) {
+			defer batcherWait.Done()
+			b.run()
+		}(batchers[i])
+	}
+
+	var senderWait sync.WaitGroup
+	for index, txs := range txsMap {
+		senderWait.Add(1)
+		go func(index int, txs []*types.Transaction) {
+			defer senderWait.Done()
+			queue := batchers[index%len(batchers)].queue
 			for _, tx := range txs {
-				for {
-					if t.limiter == nil || t.limiter.AllowRequest() {
-						client, err := t.getClientFromPool()
-						if err != nil {
-							log.Printf("Client pool error: %v", err)
-							time.Sleep(10 * time.Millisecond)
-							continue
-						}
-						err = broadcastWithRetry(client, tx)
-						if err != nil {
-							log.Printf("Failed to broadcast transaction %s after retries: %v", tx.Hash().Hex(), err)
-							// Continue with next transaction instead of exiting
-							break
-						}
-						break
-					} else {
-						time.Sleep(10 * time.Millisecond)
-					}
+				if kind, ok := t.invalid[tx.Hash()]; ok {
+					// outside of the mempool limit, but in order as it
+					// shares the nonce of the next tx
+					queue <- &batchItem{sender: index, tx: tx, invalid: kind}
+					continue
+				}
+				for t.limiter != nil && !t.limiter.AllowRequest() {
+					time.Sleep(10 * time.Millisecond)
 				}
+				queue <- &batchItem{sender: index, tx: tx}
 			}
-			ch <- nil
-		}(txs)
+		}(index, txs)
 	}
+	senderWait.Wait()
+	for _, b := range batchers {
+		close(b.queue)
+	}
+	batcherWait.Wait()
 
-	senderCount := len(txsMap)
-	for i := 0; i < senderCount; i++ {
-		err := <-ch
-		if err != nil {
-			log.Printf("Batch processing error: %v", err)
-			// Continue processing other batches instead of returning immediately
+	elapsed := time.Since(start).Seconds()
+	fmt.Printf("Submitted %d txs, %d bytes in %.2fs: %.2f KB/s\n", t.sentTxs, t.sentBytes, elapsed, float64(t.sentBytes)/elapsed/1024)
+	t.printRejections()
+
+	return nil
+}
+
+// batchItem is a tx waiting in a batcher.
+type batchItem struct {
+	sender int
+	tx     *types.Transaction
+	// the kind of an invalid tx, sent on its own
+	invalid string
+
+	// the failed sends and when to send again
+	attempts int
+	retryAt  time.Time
+}
+
+// batcher sends the txs of a group of senders in JSON-RPC batch requests. A
+// request holds at most one tx per sender, the next tx of a sender is only
+// sent once the node accepted the one before it or it was given up, so the
+// nonces of a sender reach the node in order.
+type batcher struct {
+	t     *Transmitter
+	queue chan *batchItem
+	// the txs of every sender waiting to be sent, in order
+	pending map[int][]*batchItem
+	// the nonces sent already per sender, a tx sent with one of them
+	// replaces another
+	sent map[int]map[uint64]bool
+}
+
+func (b *batcher) run() {
+	queue := b.queue
+	for queue != nil || len(b.pending) > 0 {
+		if len(b.pending) == 0 {
+			item, ok := <-queue
+			if !ok {
+				queue = nil
+				continue
+			}
+			b.add(item)
+		}
+		// the txs queued meanwhile join the batch
+		for drained := false; !drained && queue != nil; {
+			select {
+			case item, ok := <-queue:
+				if !ok {
+					queue = nil
+					break
+				}
+				b.add(item)
+			default:
+				drained = true
+			}
+		}
+
+		batch, wait := b.next()
+		if len(batch) > 0 {
+			b.send(batch)
+			continue
+		}
+		if len(b.pending) == 0 {
+			continue
+		}
+		// every sender waits to send its tx again
+		select {
+		case item, ok := <-queue:
+			if !ok {
+				queue = nil
+				break
+			}
+			b.add(item)
+		case <-time.After(wait):
 		}
 	}
+}
 
-	return nil
+func (b *batcher) add(item *batchItem) {
+	b.pending[item.sender] = append(b.pending[item.sender], item)
+	if b.sent[item.sender] == nil {
+		b.sent[item.sender] = make(map[uint64]bool)
+	}
 }
 
-func broadcastWithRetry(client *ethclient.Client, tx *types.Transaction) error {
-	const maxRetries = 4
+func (b *batcher) pop(sender int) {
+	b.pending[sender] = b.pending[sender][1:]
+	if len(b.pending[sender]) == 0 {
+		delete(b.pending, sender)
+	}
+}
+
+// next returns the first tx of every sender which is not waiting to be sent
+// again, or else how long until the first of them is due.
+func (b *batcher) next() ([]*batchItem, time.Duration) {
+	now := time.Now()
+	var batch []*batchItem
+	wait := time.Duration(math.MaxInt64)
+	for sender := range b.pending {
+		for len(b.pending[sender]) > 0 && b.pending[sender][0].invalid != "" {
+			item := b.pending[sender][0]
+			b.t.sendInvalid(item.tx, item.invalid)
+			b.pop(sender)
+		}
+		if len(b.pending[sender]) == 0 {
+			continue
+		}
 
-	for retry := 0; retry < maxRetries; retry++ {
-		err := broadcast(client, tx)
+		item := b.pending[sender][0]
+		if due := item.retryAt.Sub(now); due > 0 {
+			wait = min(wait, due)
+			continue
+		}
+		batch = append(batch, item)
+	}
+	return batch, wait
+}
+
+// send sends the txs in one batch request, or on its own when there is a
+// single one, and sends the failed ones again later unless the error is
+// permanent.
+func (b *batcher) send(batch []*batchItem) {
+	txs := make([]*types.Transaction, len(batch))
+	for i, item := range batch {
+		if item.attempts == 0 {
+			b.prepare(item)
+		}
+		txs[i] = item.tx
+	}
+
+	var client *ethclient.Client
+	for {
+		var err error
+		client, err = b.t.getClientFromPool()
 		if err == nil {
-			return nil
+			break
+		}
+		log.Printf("Client pool error: %v", err)
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	var errs []error
+	if len(txs) == 1 {
+		errs = []error{broadcast(client, txs[0])}
+	} else {
+		var err error
+		errs, err = broadcastBatch(client, txs)
+		if err != nil {
+			// the request failed as a whole
+			errs = make([]error, len(txs))
+			for i := range errs {
+				errs[i] = err
+			}
 		}
+	}
 
-		// Log retry attempt
-		if retry < maxRetries-1 {
+	for i, item := range batch {
+		err := errs[i]
+		if err != nil && isKnownTx(err) {
+			// e.g. sent by a request whose response was lost
+			err = nil
+		}
+		if err != nil && !isPermanent(err) && item.attempts < maxSendAttempts-1 {
+			item.attempts++
 			log.Printf("Broadcast failed for tx %s, retrying %d/%d: %v",
-				tx.Hash().Hex(), retry+1, maxRetries, err)
-			// Shorter backoff for stress testing: 100ms, 200ms, 400ms, 800ms
-			backoff := time.Duration(1<<retry) * 100 * time.Millisecond
-			time.Sleep(backoff)
+				item.tx.Hash().Hex(), item.attempts, maxSendAttempts, err)
+			// Shorter backoff for stress testing: 100ms, 200ms, 400ms
+			item.retryAt = time.Now().Add(time.Duration(1<<(item.attempts-1)) * 100 * time.Millisecond)
+			continue
+		}
+		b.pop(item.sender)
+		b.t.recordSent(item.tx, err, b.sent[item.sender])
+	}
+}
+
+// prepare prices a tx again and reports it right before it is first sent.
+func (b *batcher) prepare(item *batchItem) {
+	if b.t.repricer != nil {
+		// the tx holds a mempool slot and its nonce, so it is sent at its
+		// old price rather than dropped
+		repriced, err := b.t.repricer.Reprice(item.tx)
+		if err != nil {
+			log.Printf("Failed to reprice transaction %s, sending it as it is: %v", item.tx.Hash().Hex(), err)
+		} else {
+			item.tx = repriced
+		}
+	}
+	if b.t.onSent != nil {
+		b.t.onSent(item.tx.Hash())
+	}
+}
+
+// recordSent counts a tx sent to the node, or gives back its mempool slot
+// when it failed.
+func (t *Transmitter) recordSent(tx *types.Transaction, err error, sent map[uint64]bool) {
+	if err != nil {
+		log.Printf("Failed to broadcast transaction %s: %v", tx.Hash().Hex(), err)
+		// the tx will never be included to give its slot back
+		t.release()
+		return
+	}
+	atomic.AddInt64(&t.sentTxs, 1)
+	atomic.AddInt64(&t.sentBytes, int64(tx.Size()))
+	if sent[tx.Nonce()] {
+		// the replaced tx leaves the mempool without being included
+		t.release()
+	}
+	sent[tx.Nonce()] = true
+}
+
+func (t *Transmitter) sendInvalid(tx *types.Transaction, kind string) {
+	reason := "accepted"
+	client, err := t.getClientFromPool()
+	if err == nil {
+		err = broadcast(client, tx)
+	}
+	if err != nil {
+		// the details, e.g. the balance, differ from tx to tx
+		reason, _, _ = strings.Cut(err.Error(), ":")
+	}
+
+	t.rejectMutex.Lock()
+	defer t.rejectMutex.Unlock()
+	if t.rejections[kind] == nil {
+		t.rejections[kind] = make(map[string]int)
+	}
+	t.rejections[kind][reason]++
+}
+
+func (t *Transmitter) printRejections() {
+	if t.invalid == nil {
+		return
+	}
+
+	t.rejectMutex.Lock()
+	defer t.rejectMutex.Unlock()
+	kinds := make([]string, 0, len(t.rejections))
+	for kind := range t.rejections {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	fmt.Println("Invalid txs:")
+	for _, kind := range kinds {
+		sent, rejected := 0, 0
+		reasons := make([]string, 0, len(t.rejections[kind]))
+		for reason, n := range t.rejections[kind] {
+			sent += n
+			if reason != "accepted" {
+				rejected += n
+			}
+			reasons = append(reasons, fmt.Sprintf("%s: %d", reason, n))
 		}
+		sort.Strings(reasons)
+		fmt.Printf("  %s: sent %d rejected %d (%s)\n", kind, sent, rejected, strings.Join(reasons, ", "))
+	}
+}
+
+// release gives back the mempool slot of a tx which will not be included.
+func (t *Transmitter) release() {
+	if t.limiter != nil {
+		t.limiter.IncreaseLimit(1)
 	}
+}
+
+// maxSendAttempts bounds the sends of a tx failing with a transient error.
+const maxSendAttempts = 4
 
-	// All retries failed
-	return fmt.Errorf("failed to broadcast transaction %s after %d retries", tx.Hash().Hex(), maxRetries)
+// permanentErrors are the errors of eth_sendRawTransaction which sending the
+// same tx again cannot fix. Insufficient funds are not, prepareSenders does
+// not wait for the funds of the senders to arrive.
+var permanentErrors = []string{
+	"nonce too low",
+	"underpriced",
+	"intrinsic gas too low",
+	"exceeds block gas limit",
+	"invalid sender",
+	"oversized data",
+	"max initcode size exceeded",
+}
+
+func isPermanent(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, permanent := range permanentErrors {
+		if strings.Contains(msg, permanent) {
+			return true
+		}
+	}
+	return false
+}
+
+// isKnownTx tells whether the node rejected a tx because it holds it already.
+func isKnownTx(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "already known") || strings.Contains(msg, "known transaction")
+}
+
+func broadcastBatch(client *ethclient.Client, txs []*types.Transaction) ([]error, error) {
+	batch := make([]rpc.BatchElem, len(txs))
+	for i, tx := range txs {
+		data, err := tx.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		batch[i] = rpc.BatchElem{
+			Method: "eth_sendRawTransaction",
+			Args:   []interface{}{hexutil.Encode(data)},
+			Result: new(common.Hash),
+		}
+	}
+
+	err := client.Client().BatchCallContext(context.Background(), batch)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := make([]error, len(txs))
+	for i := range batch {
+		errs[i] = batch[i].Error
+	}
+	return errs, nil
 }
 
 func broadcast(client *ethclient.Client, tx *types.Transaction) error {
@@ -148,4 +501,4 @@ func broadcast(client *ethclient.Client, tx *types.Transaction) error {
 	// Check tx hash
 	// the hash can be obtained: tx.Hash().Hex()
 	return nil
-}
\ No newline at end of file
+}
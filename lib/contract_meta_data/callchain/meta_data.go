@@ -0,0 +1,8 @@
+// This file is generated by "make asm-metadata" from the hand-written assembly
+// in contracts/asm/callchain, please do not edit it
+
+package callchain
+
+var CallChainABI = "[{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"counts\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"depth\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"fanout\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"mode\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"key\",\"type\":\"uint256\"}],\"name\":\"hop\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"sum\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"peers\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address[]\",\"name\":\"_peers\",\"type\":\"address[]\"}],\"name\":\"setPeers\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"
+
+var CallChainBin = "6101ae80600c6000396000f360003560e01c34610037578063ce53c90d146100b25780632e76d08814610078578063be4f3e8f14610056578063dc715d1b1461003c575b600080fd5b600435600052600160205260406000205460005260206000f35b6004358060005411156100375760006000526020600020015460005260206000f35b600435600401803580600055906020016000600052602060002060005b838110156100b0578060200283013581830155600101610095565b005b604435600210610037576064356000526001602052604060002080546002604435146100df576001018082555b6004351561019a5763ce53c90d60e01b610100526001600435036101045260243561012452604435610144526064356101645260005b6024358110156101985760005481600435010660006000526020600020015460443580156101565760011461016b5760206102006084610100845afa610179565b50602061020060846101006000855af1610179565b60206102006084610100845af45b156101a357503d60201415610037576102005182019150600101610115565b505b60005260206000f35b3d600060003e3d6000fd"
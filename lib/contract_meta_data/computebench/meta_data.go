@@ -0,0 +1,8 @@
+// This file is generated by "make asm-metadata" from the hand-written assembly
+// in contracts/asm/computebench, please do not edit it
+
+package computebench
+
+var ComputeBenchABI = "[{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"rounds\",\"type\":\"uint256\"}],\"name\":\"arithLoop\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"x\",\"type\":\"uint256\"}],\"stateMutability\":\"pure\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"rounds\",\"type\":\"uint256\"}],\"name\":\"keccakLoop\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"h\",\"type\":\"bytes32\"}],\"stateMutability\":\"pure\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"words\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"rounds\",\"type\":\"uint256\"}],\"name\":\"memoryLoop\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"sum\",\"type\":\"uint256\"}],\"stateMutability\":\"pure\",\"type\":\"function\"}]"
+
+var ComputeBenchBin = "61012980600c6000396000f360003560e01c3461002c57806322afaa4e14610031578063b4ffdcb214610063578063e3b46c9f146100da575b600080fd5b600060043560005b8181101561005857826000528060205260406000209250600101610039565b505060005260206000f35b6004358060005b818110156100cf576fffffffffffffffffffffffffffffff6181679e3779b97f4a7c158502087ffffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f81800990508060071c186003820a016003810401925060010161006a565b505060005260206000f35b6004351561002c5760006004356024356000600160206020850203525b8181101561011d57602083611eef830206028181510180825285019450506001016100f7565b50505060005260206000f3"
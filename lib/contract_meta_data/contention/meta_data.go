@@ -0,0 +1,8 @@
+// This file is generated by "make asm-metadata" from the hand-written assembly
+// in contracts/asm/contention, please do not edit it
+
+package contention
+
+var ContentionABI = "[{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"slots\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"key\",\"type\":\"uint256\"}],\"name\":\"touch\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"
+
+var ContentionBin = "61005980600c6000396000f360003560e01c34610021578063edaa0c1d14610026578063387dd9e91461003f575b600080fd5b6004356000526000602052604060002080546001019055005b600435600052600060205260406000205460005260206000f3"
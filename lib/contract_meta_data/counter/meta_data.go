@@ -0,0 +1,8 @@
+// This file is generated by "make asm-metadata" from the hand-written assembly
+// in contracts/asm/counter, please do not edit it
+
+package counter
+
+var CounterABI = "[{\"inputs\":[],\"name\":\"count\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"counts\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"increment\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"incrementSender\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"
+
+var CounterBin = "6100a980600c6000396000f360003560e01c8063d09de08a146100375780631f614b6f1461004757806306661abd146100635780630568e65e14610074575b600080fd5b3461003257600054600101600055005b3461003257336000526001602052604060002080546001019055005b346100325760005460005260206000f35b346100325760043573ffffffffffffffffffffffffffffffffffffffff16600052600160205260406000205460005260206000f3"
@@ -0,0 +1,8 @@
+// This file is generated by "make asm-metadata" from the hand-written assembly
+// in contracts/asm/create2factory, please do not edit it
+
+package create2factory
+
+var Create2FactoryABI = "[{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"salt\",\"type\":\"bytes32\"},{\"internalType\":\"bytes\",\"name\":\"initCode\",\"type\":\"bytes\"}],\"name\":\"deploy\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"deployed\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"
+
+var Create2FactoryBin = "61004380600c6000396000f360003560e01c34610016578063cdcb760a1461001b575b600080fd5b602435600401803580916020016000376004359060006000f580156100165760005260206000f3"
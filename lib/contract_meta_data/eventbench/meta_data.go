@@ -0,0 +1,8 @@
+// This file is generated by "make asm-metadata" from the hand-written assembly
+// in contracts/asm/eventbench, please do not edit it
+
+package eventbench
+
+var EventBenchABI = "[{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"count\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"topics\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"size\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"seed\",\"type\":\"uint256\"}],\"name\":\"emitLogs\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"
+
+var EventBenchBin = "6100bc80600c6000396000f360003560e01c346100165780636e91d8f41461001b575b600080fd5b6024356004106100165760005b6004358110156100ba576064358160040201602435806000146100a9578060011461009c578060021461008b57806003146100765750600381016002820160018301836044356000a46100b1565b506002810160018201826044356000a36100b1565b5060018101816044356000a26100b1565b50806044356000a16100b1565b506044356000a05b50600101610028565b00"
@@ -0,0 +1,8 @@
+// This file is generated by "make asm-metadata" from the hand-written assembly
+// in contracts/asm/failbench, please do not edit it
+
+package failbench
+
+var FailBenchABI = "[{\"inputs\":[],\"name\":\"burn\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"count\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"fail\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"depth\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"shouldFail\",\"type\":\"bool\"}],\"name\":\"nest\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"succeed\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"
+
+var FailBenchBin = "6100c980600c6000396000f360003560e01c34610042578063acf495f314610047578063a9cc47181461005257806344df8e7014610060578063ee8699751461006e57806306661abd146100bd575b600080fd5b600054600101600055005b600054600101600055600080fd5b600054600101600055610060565b600435602435816100885760005460010160005561004257005b63ee86997560e01b600052600182036004528060245260006000604460006000305af1156100b257005b3d600060003e3d6000fd5b60005460005260206000f3"
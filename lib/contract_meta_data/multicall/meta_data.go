@@ -0,0 +1,8 @@
+// This file is generated by "make asm-metadata" from the hand-written assembly
+// in contracts/asm/multicall, please do not edit it
+
+package multicall
+
+var MulticallABI = "[{\"inputs\":[{\"components\":[{\"internalType\":\"address\",\"name\":\"target\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"callData\",\"type\":\"bytes\"}],\"internalType\":\"struct Multicall.Call[]\",\"name\":\"calls\",\"type\":\"tuple[]\"}],\"name\":\"aggregate\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"
+
+var MulticallBin = "61007880600c6000396000f360003560e01c34610016578063252dba421461001b575b600080fd5b60043560040180359060200160005b8281101561006b5780602002820135820180358160200135820180358082602001600037600060008260006000875af11561006d575050505060010161002a565b005b3d600060003e3d6000fd"
@@ -0,0 +1,8 @@
+// This file is generated by "make asm-metadata" from the hand-written assembly
+// in contracts/asm/noop, please do not edit it
+
+package noop
+
+var NoOpABI = "[{\"stateMutability\":\"payable\",\"type\":\"fallback\"}]"
+
+var NoOpBin = "61000180600c6000396000f300"
@@ -0,0 +1,8 @@
+// This file is generated by "make asm-metadata" from the hand-written assembly
+// in contracts/asm/precompilebench, please do not edit it
+
+package precompilebench
+
+var PrecompileBenchABI = "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"target\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"times\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"input\",\"type\":\"bytes\"}],\"name\":\"run\",\"outputs\":[],\"stateMutability\":\"view\",\"type\":\"function\"}]"
+
+var PrecompileBenchBin = "61005480600c6000396000f360003560e01c346100165780637ceba0181461001b575b600080fd5b6044356004018035809160200160003760243560005b8181101561005257600060008460006004355afa1561001657600101610031565b00"
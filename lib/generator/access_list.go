@@ -0,0 +1,169 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/0glabs/evmchainbench/lib/account"
+)
+
+const (
+	AccessListNone = "none"
+	// AccessListRPC asks the node with eth_createAccessList for every tx.
+	AccessListRPC = "rpc"
+	// AccessListLocal lets the workload compute the list, see AccessLister.
+	AccessListLocal = "local"
+)
+
+// AccessLister is implemented by workloads that know the state touched by
+// their transactions, so access lists can be built without the node.
+type AccessLister interface {
+	// AccessList returns the accounts and slots accessed by one of the
+	// transactions generated by the workload.
+	AccessList(tx *types.Transaction, from common.Address) types.AccessList
+}
+
+func validateAccessList(mode string, w Workload, txTypes *TxTypeMix) error {
+	switch mode {
+	case "", AccessListNone:
+		return nil
+	case AccessListRPC:
+	case AccessListLocal:
+		if _, ok := w.(AccessLister); !ok {
+			return fmt.Errorf("workload %q cannot compute access lists locally, use %s", w.Name(), AccessListRPC)
+		}
+	default:
+		return fmt.Errorf("unknown access list source %q, expected %s, %s or %s", mode, AccessListNone, AccessListRPC, AccessListLocal)
+	}
+
+	for _, txType := range txTypes.txTypes {
+		if txType == types.LegacyTxType {
+			return fmt.Errorf("legacy txs cannot carry access lists, use 2930 or 1559 envelopes")
+		}
+	}
+	if _, ok := w.(*blobWorkload); ok {
+		return fmt.Errorf("workload %q sends blob txs, which are not given access lists", w.Name())
+	}
+	return nil
+}
+
+// accessListBuilder adds access lists to generated transactions. Every
+// sender goroutine uses its own builder.
+type accessListBuilder struct {
+	mode    string
+	chainID *big.Int
+	lister  AccessLister
+	rpc     *rpc.Client
+
+	// the txs which reverted when simulated by eth_createAccessList, their
+	// lists only hold the state accessed before the revert
+	reverted int
+}
+
+// accessListResult is the result of eth_createAccessList.
+type accessListResult struct {
+	AccessList types.AccessList `json:"accessList"`
+	Error      string           `json:"error,omitempty"`
+}
+
+func (g *Generator) newAccessListBuilder(w Workload) (*accessListBuilder, error) {
+	b := &accessListBuilder{mode: g.AccessList, chainID: g.ChainID}
+	if b.mode == AccessListLocal {
+		b.lister = w.(AccessLister)
+	}
+	if b.mode == AccessListRPC {
+		client, err := rpc.Dial(g.RpcUrl)
+		if err != nil {
+			return nil, err
+		}
+		b.rpc = client
+	}
+	return b, nil
+}
+
+func (b *accessListBuilder) Close() {
+	if b.rpc != nil {
+		b.rpc.Close()
+	}
+}
+
+// Add returns tx signed again with its access list. Deployments are returned
+// as they are.
+func (b *accessListBuilder) Add(tx *types.Transaction, sender *account.Account) (*types.Transaction, error) {
+	if tx.To() == nil {
+		return tx, nil
+	}
+
+	var accessList types.AccessList
+	switch b.mode {
+	case AccessListLocal:
+		accessList = b.lister.AccessList(tx, sender.Address)
+	case AccessListRPC:
+		// the node simulates the tx, prepareSenders waited for the funds; the
+		// gas of the tx does not cover the list, so the node picks the limit
+		arg := map[string]interface{}{
+			"from":  sender.Address,
+			"to":    tx.To(),
+			"value": (*hexutil.Big)(tx.Value()),
+			"input": hexutil.Bytes(tx.Data()),
+		}
+		var result accessListResult
+		err := b.rpc.CallContext(context.Background(), &result, "eth_createAccessList", arg, "latest")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create access list: %w", err)
+		}
+		// e.g. a withdrawal before its deposit reverts against the current state
+		if result.Error != "" {
+			b.reverted++
+		}
+		accessList = result.AccessList
+	}
+
+	// the list is paid upfront and can only make the execution cheaper
+	gas := tx.Gas() + uint64(len(accessList))*params.TxAccessListAddressGas +
+		uint64(accessList.StorageKeys())*params.TxAccessListStorageKeyGas
+
+	var data types.TxData
+	switch tx.Type() {
+	case types.AccessListTxType:
+		data = &types.AccessListTx{ChainID: b.chainID, Nonce: tx.Nonce(), GasPrice: tx.GasPrice(), Gas: gas,
+			To: tx.To(), Value: tx.Value(), Data: tx.Data(), AccessList: accessList}
+	case types.DynamicFeeTxType:
+		data = &types.DynamicFeeTx{ChainID: b.chainID, Nonce: tx.Nonce(), GasTipCap: tx.GasTipCap(), GasFeeCap: tx.GasFeeCap(), Gas: gas,
+			To: tx.To(), Value: tx.Value(), Data: tx.Data(), AccessList: accessList}
+	default:
+		return nil, fmt.Errorf("tx type %d cannot carry an access list", tx.Type())
+	}
+
+	return types.SignTx(types.NewTx(data), types.LatestSignerForChainID(b.chainID), sender.PrivateKey)
+}
+
+// callArg returns the i-th static argument of the calldata of a tx.
+func callArg(tx *types.Transaction, i int) common.Hash {
+	return common.BytesToHash(tx.Data()[4+32*i : 4+32*(i+1)])
+}
+
+// erc20TransferAccessList is the access list of a transfer of the bundled
+// token, whose balances are the mapping at slot 0.
+func erc20TransferAccessList(tx *types.Transaction, from common.Address) types.AccessList {
+	return types.AccessList{{
+		Address: *tx.To(),
+		StorageKeys: []common.Hash{
+			mappingSlot(common.BytesToHash(from.Bytes()), 0),
+			mappingSlot(callArg(tx, 0), 0),
+		},
+	}}
+}
+
+// mappingSlot returns the slot of key in a Solidity mapping at slot.
+func mappingSlot(key common.Hash, slot uint64) common.Hash {
+	return crypto.Keccak256Hash(key.Bytes(), common.BigToHash(new(big.Int).SetUint64(slot)).Bytes())
+}
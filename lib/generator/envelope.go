@@ -0,0 +1,114 @@
+package generator
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// TxEnvelopeAuto picks EIP-1559 transactions when the chain supports them and
+// legacy ones otherwise.
+const TxEnvelopeAuto = "auto"
+
+var txEnvelopeNames = map[string]uint8{
+	"legacy": types.LegacyTxType,
+	"2930":   types.AccessListTxType,
+	"1559":   types.DynamicFeeTxType,
+}
+
+// TxTypeMix picks the envelope of every generated transaction by weight.
+type TxTypeMix struct {
+	txTypes     []uint8
+	weights     []int
+	totalWeight int
+
+	mutex sync.Mutex
+	rng   *rand.Rand
+}
+
+// ParseTxTypeMix accepts "auto", a single envelope such as "1559", or weights
+// such as "legacy=20,1559=80".
+func ParseTxTypeMix(spec string, eip1559 bool) (*TxTypeMix, error) {
+	if spec == "" || spec == TxEnvelopeAuto {
+		spec = "legacy"
+		if eip1559 {
+			spec = "1559"
+		}
+	}
+
+	weights := make(map[uint8]int)
+	for _, part := range strings.Split(spec, ",") {
+		name, value, hasWeight := strings.Cut(strings.TrimSpace(part), "=")
+		txType, ok := txEnvelopeNames[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown tx envelope %q, expected legacy, 2930 or 1559", name)
+		}
+		weight := 1
+		if hasWeight {
+			var err error
+			weight, err = strconv.Atoi(value)
+			if err != nil || weight <= 0 {
+				return nil, fmt.Errorf("weight of %q must be a positive integer, got %q", name, value)
+			}
+		}
+		if txType == types.DynamicFeeTxType && !eip1559 {
+			return nil, fmt.Errorf("the chain does not support EIP-1559 transactions")
+		}
+		weights[txType] += weight
+	}
+
+	m := &TxTypeMix{rng: rand.New(rand.NewSource(0))}
+	for txType := range weights {
+		m.txTypes = append(m.txTypes, txType)
+	}
+	// map iteration is random, the picks should not be
+	sort.Slice(m.txTypes, func(i, j int) bool { return m.txTypes[i] < m.txTypes[j] })
+	for _, txType := range m.txTypes {
+		m.weights = append(m.weights, weights[txType])
+		m.totalWeight += weights[txType]
+	}
+
+	return m, nil
+}
+
+// Pick returns the envelope of the next transaction. It is safe for
+// concurrent use.
+func (m *TxTypeMix) Pick() uint8 {
+	if len(m.txTypes) == 1 {
+		return m.txTypes[0]
+	}
+
+	m.mutex.Lock()
+	pick := m.rng.Intn(m.totalWeight)
+	m.mutex.Unlock()
+
+	for i, weight := range m.weights {
+		if pick < weight {
+			return m.txTypes[i]
+		}
+		pick -= weight
+	}
+	return m.txTypes[len(m.txTypes)-1]
+}
+
+func (m *TxTypeMix) String() string {
+	parts := make([]string, len(m.txTypes))
+	for i, txType := range m.txTypes {
+		parts[i] = fmt.Sprintf("%s=%d", txEnvelopeName(txType), m.weights[i])
+	}
+	return strings.Join(parts, ",")
+}
+
+func txEnvelopeName(txType uint8) string {
+	for name, t := range txEnvelopeNames {
+		if t == txType {
+			return name
+		}
+	}
+	return strconv.Itoa(int(txType))
+}
@@ -0,0 +1,342 @@
+package generator
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+const (
+	// FeeFixed bids the given price in gwei.
+	FeeFixed = "fixed"
+	// FeeMultiplier bids eth_gasPrice times the given factor.
+	FeeMultiplier = "multiplier"
+	// FeeHistory tips the given percentile of the tips of the recent blocks,
+	// from eth_feeHistory, on top of twice the base fee.
+	FeeHistory = "history"
+	// FeeTrack prices like FeeHistory and signs the txs again while they are
+	// sent whenever the base fee outgrows them.
+	FeeTrack = "track"
+
+	// FeeDefault is the price the generator always used
+	FeeDefault = "multiplier=32"
+
+	feeHistoryBlocks = 20
+)
+
+// Fees is the price bid by a transaction. Legacy and access list
+// transactions pay GasPrice, dynamic fee ones bid it as fee cap and GasTipCap
+// as tip.
+type Fees struct {
+	GasPrice  *big.Int
+	GasTipCap *big.Int
+	// BlobFeeCap is the blob fee cap of blob transactions, nil keeps theirs.
+	BlobFeeCap *big.Int
+}
+
+func (f *Fees) String() string {
+	return fmt.Sprintf("gas price %s tip %s", f.GasPrice, f.GasTipCap)
+}
+
+// FeeStrategy decides the price of the generated transactions.
+type FeeStrategy struct {
+	Mode string
+	// Value is the price in wei of FeeFixed, the factor of FeeMultiplier and
+	// the percentile of FeeHistory and FeeTrack.
+	Value *big.Float
+	// Cap bounds the price in wei, nil for none.
+	Cap *big.Int
+}
+
+// ParseFeeStrategy accepts a strategy with an optional value and cap, e.g.
+// "multiplier=32", "fixed=2.5", "history=50,cap=100" or "track". Prices are
+// in gwei.
+func ParseFeeStrategy(spec string) (*FeeStrategy, error) {
+	if spec == "" {
+		spec = FeeDefault
+	}
+
+	s := &FeeStrategy{}
+	for i, part := range strings.Split(spec, ",") {
+		key, value, hasValue := strings.Cut(strings.TrimSpace(part), "=")
+		if key == "cap" {
+			if !hasValue {
+				return nil, fmt.Errorf("cap needs a price in gwei")
+			}
+			capPrice, err := parseGwei(value)
+			if err != nil {
+				return nil, err
+			}
+			s.Cap = capPrice
+			continue
+		}
+		if i != 0 {
+			return nil, fmt.Errorf("unexpected %q, only a cap may follow the strategy", part)
+		}
+
+		s.Mode = key
+		var defaultValue string
+		switch key {
+		case FeeFixed:
+			if !hasValue {
+				return nil, fmt.Errorf("%s needs a price in gwei", FeeFixed)
+			}
+			price, err := parseGwei(value)
+			if err != nil {
+				return nil, err
+			}
+			s.Value = new(big.Float).SetInt(price)
+			continue
+		case FeeMultiplier:
+			defaultValue = "32"
+		case FeeHistory, FeeTrack:
+			defaultValue = "50"
+		default:
+			return nil, fmt.Errorf("unknown fee strategy %q, expected %s, %s, %s or %s", key, FeeFixed, FeeMultiplier, FeeHistory, FeeTrack)
+		}
+		if !hasValue {
+			value = defaultValue
+		}
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil || v <= 0 {
+			return nil, fmt.Errorf("value of %s must be a positive number, got %q", key, value)
+		}
+		if key != FeeMultiplier && v > 100 {
+			return nil, fmt.Errorf("percentile of %s must not exceed 100, got %q", key, value)
+		}
+		s.Value = big.NewFloat(v)
+	}
+	if s.Mode == "" {
+		return nil, fmt.Errorf("no fee strategy in %q", spec)
+	}
+
+	return s, nil
+}
+
+func (s *FeeStrategy) String() string {
+	str := s.Mode + "=" + s.Value.Text('g', 10)
+	if s.Mode == FeeFixed {
+		str = s.Mode + "=" + formatGwei(s.Value)
+	}
+	if s.Cap != nil {
+		str += ",cap=" + formatGwei(new(big.Float).SetInt(s.Cap))
+	}
+	return str
+}
+
+// Fees returns the price of the transactions from the current state of the
+// chain.
+func (s *FeeStrategy) Fees(client *ethclient.Client, eip1559 bool) (*Fees, error) {
+	var fees *Fees
+	switch s.Mode {
+	case FeeFixed:
+		price, _ := s.Value.Int(nil)
+		fees = &Fees{GasPrice: price, GasTipCap: price}
+	case FeeMultiplier:
+		gasPrice, err := client.SuggestGasPrice(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		price, _ := new(big.Float).Mul(new(big.Float).SetInt(gasPrice), s.Value).Int(nil)
+		fees = &Fees{GasPrice: price, GasTipCap: price}
+	case FeeHistory, FeeTrack:
+		if !eip1559 {
+			return nil, fmt.Errorf("the %s fee strategy needs a chain with EIP-1559", s.Mode)
+		}
+		percentile, _ := s.Value.Float64()
+		history, err := client.FeeHistory(context.Background(), feeHistoryBlocks, nil, []float64{percentile})
+		if err != nil {
+			return nil, err
+		}
+		// empty blocks report no tips at all
+		tip, blocks := new(big.Int), 0
+		for i, reward := range history.Reward {
+			if history.GasUsedRatio[i] > 0 {
+				tip.Add(tip, reward[0])
+				blocks++
+			}
+		}
+		if blocks > 0 {
+			tip.Div(tip, big.NewInt(int64(blocks)))
+		} else {
+			tip, err = client.SuggestGasTipCap(context.Background())
+			if err != nil {
+				return nil, err
+			}
+		}
+		// the last base fee is the one of the next block
+		baseFee := history.BaseFee[len(history.BaseFee)-1]
+		fees = &Fees{GasPrice: s.feeCap(baseFee, tip), GasTipCap: tip}
+	}
+
+	s.applyCap(fees)
+	return fees, nil
+}
+
+// feeCap leaves room for the base fee to double, as wallets do.
+func (s *FeeStrategy) feeCap(baseFee, tip *big.Int) *big.Int {
+	feeCap := new(big.Int).Mul(baseFee, big.NewInt(2))
+	return feeCap.Add(feeCap, tip)
+}
+
+func (s *FeeStrategy) applyCap(fees *Fees) {
+	if s.Cap == nil {
+		return
+	}
+	if fees.GasPrice.Cmp(s.Cap) > 0 {
+		fees.GasPrice = new(big.Int).Set(s.Cap)
+	}
+	if fees.GasTipCap.Cmp(fees.GasPrice) > 0 {
+		fees.GasTipCap = new(big.Int).Set(fees.GasPrice)
+	}
+}
+
+// Repricer signs transactions again with the current price when the base fee
+// has outgrown the one they were generated with. It is used by the FeeTrack
+// strategy while the transactions are sent.
+type Repricer struct {
+	strategy *FeeStrategy
+	chainID  *big.Int
+	tip      *big.Int
+	keys     map[common.Address]*ecdsa.PrivateKey
+
+	mutex       sync.RWMutex
+	baseFee     *big.Int
+	blobBaseFee *big.Int
+
+	// OnReplace is called with the hashes of every transaction signed again.
+	OnReplace func(from, to common.Hash)
+}
+
+// NewRepricer returns nil unless the transactions track the base fee.
+func (g *Generator) NewRepricer() *Repricer {
+	if g.FeeStrategy.Mode != FeeTrack {
+		return nil
+	}
+	keys := make(map[common.Address]*ecdsa.PrivateKey, len(g.Senders))
+	for _, sender := range g.Senders {
+		keys[sender.Address] = sender.PrivateKey
+	}
+	return &Repricer{strategy: g.FeeStrategy, chainID: g.ChainID, tip: g.Fees.GasTipCap, keys: keys}
+}
+
+// SetBaseFee records the base fee and the blob base fee, nil before Cancun,
+// of the latest block. It is safe for concurrent use.
+func (r *Repricer) SetBaseFee(baseFee, blobBaseFee *big.Int) {
+	r.mutex.Lock()
+	r.baseFee = baseFee
+	r.blobBaseFee = blobBaseFee
+	r.mutex.Unlock()
+}
+
+// Reprice returns tx signed again if its price would not cover the base fee
+// of the next block, its blob fee cap would not cover the blob base fee or,
+// for transactions paying their whole price, would overpay more than twice.
+// Otherwise tx is returned as it is.
+func (r *Repricer) Reprice(tx *types.Transaction) (*types.Transaction, error) {
+	r.mutex.RLock()
+	baseFee, blobBaseFee := r.baseFee, r.blobBaseFee
+	r.mutex.RUnlock()
+	if baseFee == nil {
+		return tx, nil
+	}
+
+	// the base fee rises by at most 12.5% per block
+	floor := new(big.Int).Div(new(big.Int).Mul(baseFee, big.NewInt(9)), big.NewInt(8))
+	// dynamic fee txs keep their tip, which may be the one of a tip tier
+	tip := r.tip
+	if tx.Type() == types.DynamicFeeTxType || tx.Type() == types.BlobTxType {
+		tip = tx.GasTipCap()
+	}
+	fees := &Fees{GasPrice: r.strategy.feeCap(baseFee, tip), GasTipCap: new(big.Int).Set(tip)}
+	r.strategy.applyCap(fees)
+
+	// the blob base fee rises by at most 12.5% per block as well
+	if tx.Type() == types.BlobTxType && blobBaseFee != nil {
+		blobFloor := new(big.Int).Div(new(big.Int).Mul(blobBaseFee, big.NewInt(9)), big.NewInt(8))
+		if tx.BlobGasFeeCap().Cmp(blobFloor) < 0 {
+			fees.BlobFeeCap = new(big.Int).Mul(blobBaseFee, big.NewInt(2))
+			// only the blob fee may be short, the fee cap is not lowered
+			if tx.GasFeeCap().Cmp(floor) >= 0 {
+				fees.GasPrice = tx.GasFeeCap()
+			}
+		}
+	}
+
+	switch {
+	case fees.BlobFeeCap != nil:
+	case tx.GasFeeCap().Cmp(floor) < 0:
+	case tx.Type() != types.DynamicFeeTxType && tx.Type() != types.BlobTxType &&
+		tx.GasPrice().Cmp(new(big.Int).Mul(fees.GasPrice, big.NewInt(2))) > 0:
+	default:
+		return tx, nil
+	}
+	if fees.BlobFeeCap == nil && tx.GasFeeCap().Cmp(fees.GasPrice) == 0 {
+		// capped already
+		return tx, nil
+	}
+
+	signer := types.LatestSignerForChainID(r.chainID)
+	from, err := types.Sender(signer, tx)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := r.keys[from]
+	if !ok {
+		return tx, nil
+	}
+
+	repriced, err := types.SignTx(types.NewTx(withFees(tx, fees)), signer, key)
+	if err != nil {
+		return nil, err
+	}
+	if r.OnReplace != nil {
+		r.OnReplace(tx.Hash(), repriced.Hash())
+	}
+	return repriced, nil
+}
+
+// withFees copies tx with another price.
+func withFees(tx *types.Transaction, fees *Fees) types.TxData {
+	switch tx.Type() {
+	case types.AccessListTxType:
+		return &types.AccessListTx{ChainID: tx.ChainId(), Nonce: tx.Nonce(), GasPrice: fees.GasPrice, Gas: tx.Gas(),
+			To: tx.To(), Value: tx.Value(), Data: tx.Data(), AccessList: tx.AccessList()}
+	case types.DynamicFeeTxType:
+		return &types.DynamicFeeTx{ChainID: tx.ChainId(), Nonce: tx.Nonce(), GasTipCap: fees.GasTipCap, GasFeeCap: fees.GasPrice, Gas: tx.Gas(),
+			To: tx.To(), Value: tx.Value(), Data: tx.Data(), AccessList: tx.AccessList()}
+	case types.BlobTxType:
+		blobFeeCap := tx.BlobGasFeeCap()
+		if fees.BlobFeeCap != nil {
+			blobFeeCap = fees.BlobFeeCap
+		}
+		return &types.BlobTx{ChainID: uint256.MustFromBig(tx.ChainId()), Nonce: tx.Nonce(), GasTipCap: uint256.MustFromBig(fees.GasTipCap),
+			GasFeeCap: uint256.MustFromBig(fees.GasPrice), Gas: tx.Gas(), To: *tx.To(), Value: uint256.MustFromBig(tx.Value()), Data: tx.Data(),
+			AccessList: tx.AccessList(), BlobFeeCap: uint256.MustFromBig(blobFeeCap), BlobHashes: tx.BlobHashes(), Sidecar: tx.BlobTxSidecar()}
+	default:
+		return &types.LegacyTx{Nonce: tx.Nonce(), GasPrice: fees.GasPrice, Gas: tx.Gas(), To: tx.To(), Value: tx.Value(), Data: tx.Data()}
+	}
+}
+
+func parseGwei(s string) (*big.Int, error) {
+	gwei, ok := new(big.Float).SetString(s)
+	if !ok || gwei.Sign() <= 0 {
+		return nil, fmt.Errorf("price must be a positive number of gwei, got %q", s)
+	}
+	wei, _ := gwei.Mul(gwei, big.NewFloat(params.GWei)).Int(nil)
+	return wei, nil
+}
+
+func formatGwei(wei *big.Float) string {
+	return new(big.Float).Quo(wei, big.NewFloat(params.GWei)).Text('g', 10)
+}
@@ -1,11 +1,37 @@
 package generator
 
 const (
-	simpleTransferGasLimit    = uint64(21000)
-	erc20ContractGasLimit     = uint64(810000)
-	erc20TransferGasLimit     = uint64(210000)
-	uniswapContractGasLimit   = uint64(10000000)
-	uniswapCreatePairGasLimit = uint64(10000000)
-	uniswapMintGasLimit       = uint64(210000)
-	uniswapSwapGasLimit       = uint64(10000000)
+	simpleTransferGasLimit          = uint64(21000)
+	erc20ContractGasLimit           = uint64(810000)
+	erc20TransferGasLimit           = uint64(210000)
+	counterContractGasLimit         = uint64(300000)
+	counterIncrementGasLimit        = uint64(100000)
+	contentionContractGasLimit      = uint64(300000)
+	contentionTouchGasLimit         = uint64(100000)
+	nftContractGasLimit             = uint64(1000000)
+	nftCallGasLimit                 = uint64(1000000)
+	nftMintBatchGasLimit            = uint64(6000000)
+	stateBenchContractGasLimit      = uint64(300000)
+	stateBenchCallGasLimit          = uint64(10000000)
+	stateBenchPrepareGasLimit       = uint64(6000000)
+	computeBenchContractGasLimit    = uint64(300000)
+	precompileBenchContractGasLimit = uint64(300000)
+	precompileCallGasLimit          = uint64(200000)
+	create2FactoryContractGasLimit  = uint64(300000)
+	failBenchContractGasLimit       = uint64(300000)
+	failBenchCallGasLimit           = uint64(5000000)
+	noOpContractGasLimit            = uint64(100000)
+	wethContractGasLimit            = uint64(300000)
+	wethCallGasLimit                = uint64(100000)
+	multicallContractGasLimit       = uint64(300000)
+	callChainContractGasLimit       = uint64(300000)
+	callChainSetPeersGasLimit       = uint64(1000000)
+	eventBenchContractGasLimit      = uint64(300000)
+	deployGasLimit                  = uint64(10000000)
+	customContractGasLimit          = uint64(10000000)
+	customCallGasLimit              = uint64(10000000)
+	uniswapContractGasLimit         = uint64(10000000)
+	uniswapCreatePairGasLimit       = uint64(10000000)
+	uniswapMintGasLimit             = uint64(210000)
+	uniswapSwapGasLimit             = uint64(10000000)
 )
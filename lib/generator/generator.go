@@ -28,13 +28,28 @@ type Generator struct {
 	Recipients    []string
 	RpcUrl        string
 	ChainID       *big.Int
-	GasPrice      *big.Int
+	Fees          *Fees
+	FeeStrategy   *FeeStrategy
 	ShouldPersist bool
 	Store         *store.Store
 	EIP1559       bool
+	TxTypes       *TxTypeMix
+	// AccessList is the source of the access lists added to the generated
+	// transactions, AccessListNone by default.
+	AccessList string
+	// TipTiers, when set, gives the senders tips of their own, and TxTiers
+	// maps every generated transaction to its tier.
+	TipTiers *TipTiers
+	TxTiers  map[common.Hash]string
+	// Invalid, when set, injects txs the node must reject, and InvalidTxs
+	// maps every one of them to its kind.
+	Invalid    *InvalidMix
+	InvalidTxs map[common.Hash]string
+
+	sendersPrepared bool
 }
 
-func NewGenerator(rpcUrl, faucetPrivateKey string, senderCount, txCount int, shouldPersist bool, txStoreDir string) (*Generator, error) {
+func NewGenerator(rpcUrl, faucetPrivateKey string, senderCount, txCount int, txEnvelope, fee string, shouldPersist bool, txStoreDir string) (*Generator, error) {
 	client, err := ethclient.Dial(rpcUrl)
 	if err != nil {
 		return &Generator{}, err
@@ -51,12 +66,22 @@ func NewGenerator(rpcUrl, faucetPrivateKey string, senderCount, txCount int, sho
 
 	fmt.Println("EIP-1559:", eip1559)
 
-	gasPrice, err := client.SuggestGasPrice(context.Background())
+	txTypes, err := ParseTxTypeMix(txEnvelope, eip1559)
 	if err != nil {
 		return &Generator{}, err
 	}
-	// double gas
-	gasPrice = gasPrice.Mul(gasPrice, big.NewInt(32))
+	fmt.Println("Tx envelopes:", txTypes)
+
+	feeStrategy, err := ParseFeeStrategy(fee)
+	if err != nil {
+		return &Generator{}, err
+	}
+	fees, err := feeStrategy.Fees(client, eip1559)
+	if err != nil {
+		return &Generator{}, err
+	}
+	fmt.Println("Fee strategy:", feeStrategy, fees)
+
 	chainID, err := client.NetworkID(context.Background())
 	if err != nil {
 		return &Generator{}, err
@@ -94,13 +119,21 @@ func NewGenerator(rpcUrl, faucetPrivateKey string, senderCount, txCount int, sho
 		Recipients:    recipients,
 		RpcUrl:        rpcUrl,
 		ChainID:       chainID,
-		GasPrice:      gasPrice,
+		Fees:          fees,
+		FeeStrategy:   feeStrategy,
 		ShouldPersist: shouldPersist,
 		Store:         store.NewStore(txStoreDir),
 		EIP1559:       eip1559,
+		TxTypes:       txTypes,
 	}, nil
 }
 
+// TxType returns the envelope of the next transaction according to the
+// configured mix.
+func (g *Generator) TxType() uint8 {
+	return g.TxTypes.Pick()
+}
+
 func (g *Generator) approveERC20(token common.Address, spender common.Address) {
 	client, err := ethclient.Dial(g.RpcUrl)
 	if err != nil {
@@ -113,8 +146,9 @@ func (g *Generator) approveERC20(token common.Address, spender common.Address) {
 			sender.PrivateKey,
 			token.Hex(),
 			sender.GetNonce(),
+			g.TxType(),
 			g.ChainID,
-			g.GasPrice,
+			g.Fees,
 			erc20TransferGasLimit,
 			erc20.MyTokenABI,
 			"approve",
@@ -153,8 +187,9 @@ func (g *Generator) prepareERC20(contractAddressStr string) {
 			g.FaucetAccount.PrivateKey,
 			contractAddressStr,
 			g.FaucetAccount.GetNonce(),
+			g.TxType(),
 			g.ChainID,
-			g.GasPrice,
+			g.Fees,
 			erc20TransferGasLimit,
 			erc20.MyTokenABI,
 			"transfer",
@@ -180,7 +215,54 @@ func (g *Generator) prepareERC20(contractAddressStr string) {
 	}
 }
 
+// prepareContractCalls sends one faucet call of method per entry of argsList
+// and waits until all of them are mined.
+func (g *Generator) prepareContractCalls(gasLimit uint64, contractAddress common.Address, contractABI, method string, argsList [][]interface{}, timeout time.Duration) {
+	client, err := ethclient.Dial(g.RpcUrl)
+	if err != nil {
+		panic(err)
+	}
+	defer client.Close()
+	txs := types.Transactions{}
+	for _, args := range argsList {
+		tx := GenerateContractCallingTx(
+			g.FaucetAccount.PrivateKey,
+			contractAddress.Hex(),
+			g.FaucetAccount.GetNonce(),
+			g.TxType(),
+			g.ChainID,
+			g.Fees,
+			gasLimit,
+			contractABI,
+			method,
+			args...,
+		)
+
+		err = client.SendTransaction(context.Background(), tx)
+		if err != nil {
+			panic(err)
+		}
+
+		if g.ShouldPersist {
+			g.Store.AddPrepareTx(tx)
+		}
+
+		txs = append(txs, tx)
+	}
+	log.Default().Println("Waiting for", len(txs), method, "receipts...")
+	err = util.WaitForReceiptsOfTxs(client, txs, timeout)
+	if err != nil {
+		panic(err)
+	}
+}
+
 func (g *Generator) prepareSenders() {
+	// workloads combined by "mixed" all ask for funded senders
+	if g.sendersPrepared {
+		return
+	}
+	g.sendersPrepared = true
+
 	log.Default().Println("Preparing senders...")
 	client, err := ethclient.Dial(g.RpcUrl)
 	if err != nil {
@@ -194,7 +276,7 @@ func (g *Generator) prepareSenders() {
 	txs := types.Transactions{}
 
 	for _, recipient := range g.Senders {
-		signedTx, err := GenerateSimpleTransferTx(g.FaucetAccount.PrivateKey, recipient.Address.Hex(), g.FaucetAccount.GetNonce(), g.ChainID, g.GasPrice, value, g.EIP1559)
+		signedTx, err := GenerateSimpleTransferTx(g.FaucetAccount.PrivateKey, recipient.Address.Hex(), g.FaucetAccount.GetNonce(), g.TxType(), g.ChainID, g.Fees, value)
 		if err != nil {
 			panic(err)
 		}
@@ -218,20 +300,34 @@ func (g *Generator) prepareSenders() {
 	// if err != nil {
 	// 	panic(err)
 	// }
+
+	// eth_createAccessList simulates the txs of the senders, which needs their funds
+	if g.AccessList == AccessListRPC {
+		err = util.WaitForReceiptsOfTxs(client, txs, 10*time.Minute)
+		if err != nil {
+			panic(err)
+		}
+	}
 }
 
 func (g *Generator) estimateGas(msg ethereum.CallMsg) uint64 {
-	client, err := ethclient.Dial(g.RpcUrl)
+	gas, err := g.tryEstimateGas(msg)
 	if err != nil {
 		panic(err)
 	}
-	defer client.Close()
+	return gas
+}
 
-	gas, err := client.EstimateGas(context.Background(), msg)
+// tryEstimateGas is estimateGas for calls which may legitimately fail, e.g.
+// the ones built from user input.
+func (g *Generator) tryEstimateGas(msg ethereum.CallMsg) (uint64, error) {
+	client, err := ethclient.Dial(g.RpcUrl)
 	if err != nil {
-		panic(err)
+		return 0, err
 	}
-	return gas
+	defer client.Close()
+
+	return client.EstimateGas(context.Background(), msg)
 }
 
 func (g *Generator) deployContract(gasLimit uint64, contractBin, contractABI string, args ...interface{}) (common.Address, error) {
@@ -244,8 +340,9 @@ func (g *Generator) deployContract(gasLimit uint64, contractBin, contractABI str
 	tx, err := GenerateContractCreationTx(
 		g.FaucetAccount.PrivateKey,
 		g.FaucetAccount.GetNonce(),
+		g.TxType(),
 		g.ChainID,
-		g.GasPrice,
+		g.Fees,
 		gasLimit,
 		contractBin,
 		contractABI,
@@ -274,19 +371,27 @@ func (g *Generator) deployContract(gasLimit uint64, contractBin, contractABI str
 }
 
 func (g *Generator) executeContractFunction(gasLimit uint64, contractAddress common.Address, contractABI, methodName string, args ...interface{}) {
+	g.executePayableContractFunction(gasLimit, big.NewInt(0), contractAddress, contractABI, methodName, args...)
+}
+
+// executePayableContractFunction is executeContractFunction sending value
+// from the faucet along with the call.
+func (g *Generator) executePayableContractFunction(gasLimit uint64, value *big.Int, contractAddress common.Address, contractABI, methodName string, args ...interface{}) {
 	client, err := ethclient.Dial(g.RpcUrl)
 	if err != nil {
 		panic(err)
 	}
 	defer client.Close()
 
-	tx := GenerateContractCallingTx(
+	tx := GeneratePayableContractCallingTx(
 		g.FaucetAccount.PrivateKey,
 		contractAddress.Hex(),
 		g.FaucetAccount.GetNonce(),
+		g.TxType(),
 		g.ChainID,
-		g.GasPrice,
+		g.Fees,
 		gasLimit,
+		value,
 		contractABI,
 		methodName,
 		args...,
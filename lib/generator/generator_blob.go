@@ -0,0 +1,161 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/0glabs/evmchainbench/lib/account"
+)
+
+func init() {
+	Register(func() Workload { return &blobWorkload{} })
+}
+
+const (
+	blobMaxPerTx = params.MaxBlobGasPerBlock / params.BlobTxBlobGasPerBlob
+	// blobs shared by the transactions unless every tx computes its own
+	blobPoolSize = 16
+)
+
+// blobWorkload sends EIP-4844 transactions whose sidecars carry `blobs`
+// blobs each. Computing the KZG commitments and proofs is slow, so the blobs
+// come from a precomputed pool unless `unique` is set.
+type blobWorkload struct {
+	blobs         int
+	unique        bool
+	feeMultiplier int
+
+	blobFeeCap *big.Int
+	pool       []blobItem
+}
+
+type blobItem struct {
+	blob       kzg4844.Blob
+	commitment kzg4844.Commitment
+	proof      kzg4844.Proof
+}
+
+func (w *blobWorkload) Name() string {
+	return "blob"
+}
+
+func (w *blobWorkload) Describe() string {
+	return "EIP-4844 blob transactions to random recipients, sent as type 3 whatever the envelope. Options: blobs=blobs per tx up to 6 (default 1), " +
+		"unique=true to compute new blobs for every tx instead of reusing a pool of 16 (default false), " +
+		"blob-fee-multiplier=blob fee cap as a multiple of the current blob base fee (default 32)"
+}
+
+func (w *blobWorkload) Validate(params Params) error {
+	err := params.Check("blobs", "unique", "blob-fee-multiplier")
+	if err != nil {
+		return err
+	}
+
+	w.blobs, err = params.Int("blobs", 1)
+	if err != nil {
+		return err
+	}
+	if w.blobs < 1 || w.blobs > blobMaxPerTx {
+		return fmt.Errorf("blobs must be between 1 and %d", blobMaxPerTx)
+	}
+
+	w.unique, err = params.Bool("unique", false)
+	if err != nil {
+		return err
+	}
+
+	w.feeMultiplier, err = params.Int("blob-fee-multiplier", 32)
+	if err != nil {
+		return err
+	}
+	if w.feeMultiplier < 1 {
+		return fmt.Errorf("blob-fee-multiplier must be at least 1")
+	}
+
+	return nil
+}
+
+func (w *blobWorkload) Prepare(g *Generator) error {
+	client, err := ethclient.Dial(g.RpcUrl)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	header, err := client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return err
+	}
+	if header.ExcessBlobGas == nil {
+		return fmt.Errorf("the chain does not support blob transactions")
+	}
+	blobFee := eip4844.CalcBlobFee(*header.ExcessBlobGas)
+	w.blobFeeCap = new(big.Int).Mul(blobFee, big.NewInt(int64(w.feeMultiplier)))
+	fmt.Printf("Blob: blobs=%d unique=%t blob base fee: %s blob fee cap: %s\n", w.blobs, w.unique, blobFee, w.blobFeeCap)
+
+	if !w.unique {
+		w.pool = make([]blobItem, blobPoolSize)
+		for i := range w.pool {
+			w.pool[i], err = newBlobItem(big.NewInt(int64(i)).Bytes())
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	g.prepareSenders()
+
+	return nil
+}
+
+func (w *blobWorkload) GenerateTx(g *Generator, senderIndex int, sender *account.Account, seq int) (*types.Transaction, error) {
+	sidecar := &types.BlobTxSidecar{}
+	for i := 0; i < w.blobs; i++ {
+		var item blobItem
+		if w.unique {
+			var err error
+			item, err = newBlobItem(crypto.Keccak256(sender.Address.Bytes(), big.NewInt(int64(seq)).Bytes(), big.NewInt(int64(i)).Bytes()))
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			item = w.pool[(senderIndex+seq+i)%len(w.pool)]
+		}
+		sidecar.Blobs = append(sidecar.Blobs, item.blob)
+		sidecar.Commitments = append(sidecar.Commitments, item.commitment)
+		sidecar.Proofs = append(sidecar.Proofs, item.proof)
+	}
+
+	return GenerateBlobTx(sender.PrivateKey, g.Recipients[seq], sender.GetNonce(), g.ChainID, g.Fees, w.blobFeeCap, sidecar)
+}
+
+// newBlobItem fills a blob from the seed and computes its commitment and
+// proof.
+func newBlobItem(seed []byte) (blobItem, error) {
+	var item blobItem
+	data := seedBytes(seed, len(item.blob))
+	for i := 0; i < len(data); i += 32 {
+		// every field element must stay below the BLS12-381 modulus
+		data[i] = 0
+	}
+	copy(item.blob[:], data)
+
+	var err error
+	item.commitment, err = kzg4844.BlobToCommitment(&item.blob)
+	if err != nil {
+		return item, err
+	}
+	item.proof, err = kzg4844.ComputeBlobProof(&item.blob, item.commitment)
+	if err != nil {
+		return item, err
+	}
+	return item, nil
+}
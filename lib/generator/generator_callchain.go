@@ -0,0 +1,204 @@
+package generator
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/0glabs/evmchainbench/lib/account"
+	"github.com/0glabs/evmchainbench/lib/contract_meta_data/callchain"
+)
+
+func init() {
+	Register(func() Workload { return &callChainWorkload{} })
+}
+
+const (
+	callChainModeCall     = "call"
+	callChainModeDelegate = "delegatecall"
+	callChainModeStatic   = "staticcall"
+	callChainModeMix      = "mix"
+
+	// the frames of one tx, the chain grows as fanout^depth
+	callChainMaxFrames = 10000
+)
+
+var callChainModes = []string{callChainModeCall, callChainModeDelegate, callChainModeStatic}
+
+// callChainModeIDs are the modes as the contract numbers them
+var callChainModeIDs = map[string]int64{callChainModeCall: 0, callChainModeDelegate: 1, callChainModeStatic: 2}
+
+// callChainWorkload deploys several copies of a contract which call each
+// other `depth` frames deep, every frame calling `fanout` peers, through
+// CALL, DELEGATECALL or STATICCALL. Every frame bumps, or only reads under
+// STATICCALL, a slot of the sender in the contract it runs on, so a tx reads
+// and writes state across all of the contracts.
+type callChainWorkload struct {
+	contracts int
+	depth     int
+	fanout    int
+	modes     []string
+	shared    bool
+
+	addresses   []common.Address
+	estimateGas map[string]uint64
+
+	mutex  sync.Mutex
+	labels map[common.Hash]string
+}
+
+func (w *callChainWorkload) Name() string {
+	return "callchain"
+}
+
+func (w *callChainWorkload) Describe() string {
+	return "Chains of calls across several contracts. Options: contracts=copies deployed (default 4), depth=call depth (default 4), " +
+		"fanout=peers called by every frame (default 2), mode=call|delegatecall|staticcall|mix (default mix, round robin over the others), " +
+		"shared=true to have every sender write the same slots rather than slots of its own (default false)"
+}
+
+func (w *callChainWorkload) Validate(params Params) error {
+	err := params.Check("contracts", "depth", "fanout", "mode", "shared")
+	if err != nil {
+		return err
+	}
+
+	w.contracts, err = params.Int("contracts", 4)
+	if err != nil {
+		return err
+	}
+	if w.contracts < 1 || w.contracts > 64 {
+		return fmt.Errorf("contracts must be between 1 and 64")
+	}
+
+	w.depth, err = params.Int("depth", 4)
+	if err != nil {
+		return err
+	}
+	// every frame only forwards 63/64 of its gas
+	if w.depth < 0 || w.depth > 256 {
+		return fmt.Errorf("depth must be between 0 and 256")
+	}
+
+	w.fanout, err = params.Int("fanout", 2)
+	if err != nil {
+		return err
+	}
+	if w.fanout < 1 {
+		return fmt.Errorf("fanout must be at least 1")
+	}
+	if frames := w.frames(); frames > callChainMaxFrames {
+		return fmt.Errorf("depth %d with fanout %d makes more than %d frames per tx", w.depth, w.fanout, callChainMaxFrames)
+	}
+
+	switch mode := params.String("mode", callChainModeMix); mode {
+	case callChainModeCall, callChainModeDelegate, callChainModeStatic:
+		w.modes = []string{mode}
+	case callChainModeMix:
+		w.modes = callChainModes
+	default:
+		return fmt.Errorf("unknown mode %q, expected %s, %s, %s or %s", mode, callChainModeCall, callChainModeDelegate, callChainModeStatic, callChainModeMix)
+	}
+
+	w.shared, err = params.Bool("shared", false)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// frames returns the number of frames of a tx, capped above
+// callChainMaxFrames.
+func (w *callChainWorkload) frames() int {
+	frames, level := 1, 1
+	for i := 0; i < w.depth && frames <= callChainMaxFrames; i++ {
+		level *= w.fanout
+		frames += level
+	}
+	return frames
+}
+
+func (w *callChainWorkload) Prepare(g *Generator) error {
+	w.addresses = make([]common.Address, w.contracts)
+	for i := range w.addresses {
+		var err error
+		w.addresses[i], err = g.deployContract(callChainContractGasLimit, callchain.CallChainBin, callchain.CallChainABI)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("CallChain contract %d: %s\n", i, w.addresses[i].Hex())
+	}
+	for _, address := range w.addresses {
+		g.executeContractFunction(callChainSetPeersGasLimit, address, callchain.CallChainABI, "setPeers", w.addresses)
+	}
+
+	g.prepareSenders()
+
+	// The first bumps of a slot are the most expensive ones, the faucet
+	// estimates with a key no sender uses unless all of them share one.
+	// The faucet is used as it is funded already, unlike the fresh senders.
+	w.estimateGas = make(map[string]uint64)
+	for _, mode := range w.modes {
+		faucet := g.FaucetAccount
+		tx := w.tx(g, faucet, 0, mode, w.key(len(g.Senders)))
+		msg := ConvertLegacyTxToCallMsg(tx, faucet.Address)
+		// wide chains may need more than any fixed limit, the node caps the
+		// estimation at the gas limit of the block
+		msg.Gas = 0
+		w.estimateGas[mode] = g.estimateGas(msg)
+		fmt.Printf("CallChain %s: %d frames over %d contracts, estimated gas: %d\n", mode, w.frames(), w.contracts, w.estimateGas[mode])
+	}
+
+	w.labels = make(map[common.Hash]string)
+
+	return nil
+}
+
+func (w *callChainWorkload) key(senderIndex int) *big.Int {
+	if w.shared {
+		return big.NewInt(0)
+	}
+	return big.NewInt(int64(senderIndex))
+}
+
+func (w *callChainWorkload) tx(g *Generator, sender *account.Account, nonce uint64, mode string, key *big.Int) *types.Transaction {
+	// senders enter the chain at different contracts
+	entry := w.addresses[key.Int64()%int64(len(w.addresses))]
+	return GenerateContractCallingTx(
+		sender.PrivateKey,
+		entry.Hex(),
+		nonce,
+		g.TxType(),
+		g.ChainID,
+		g.Fees,
+		w.estimateGas[mode],
+		callchain.CallChainABI,
+		"hop",
+		big.NewInt(int64(w.depth)),
+		big.NewInt(int64(w.fanout)),
+		big.NewInt(callChainModeIDs[mode]),
+		key,
+	)
+}
+
+func (w *callChainWorkload) GenerateTx(g *Generator, senderIndex int, sender *account.Account, seq int) (*types.Transaction, error) {
+	// senders start at different modes so that every block gets a mix
+	mode := w.modes[(senderIndex+seq)%len(w.modes)]
+	tx := w.tx(g, sender, sender.GetNonce(), mode, w.key(senderIndex))
+
+	if len(w.modes) > 1 {
+		w.mutex.Lock()
+		w.labels[tx.Hash()] = mode
+		w.mutex.Unlock()
+	}
+
+	return tx, nil
+}
+
+func (w *callChainWorkload) Labels() map[common.Hash]string {
+	return w.labels
+}
@@ -0,0 +1,173 @@
+package generator
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/0glabs/evmchainbench/lib/account"
+	"github.com/0glabs/evmchainbench/lib/contract_meta_data/noop"
+)
+
+func init() {
+	Register(func() Workload { return &calldataWorkload{} })
+}
+
+const (
+	calldataTargetTransfer = "transfer"
+	calldataTargetContract = "contract"
+
+	calldataFillRandom = "random"
+	calldataFillZero   = "zero"
+
+	// geth drops txs larger than 128KB, the rest of the tx fits in the margin
+	calldataMaxSize = 128*1024 - 512
+)
+
+// calldataWorkload attaches large payloads to transfers or to calls of a
+// contract doing nothing, to stress the bandwidth of RPC ingestion and of
+// mempool gossip rather than execution.
+type calldataWorkload struct {
+	minSize, maxSize int
+	target           string
+	fill             string
+
+	value   *big.Int
+	noOp    common.Address
+	senders []*rand.Rand
+
+	// the estimated gas of the smallest and the largest payloads
+	minGas, maxGas uint64
+}
+
+func (w *calldataWorkload) Name() string {
+	return "calldata"
+}
+
+func (w *calldataWorkload) Describe() string {
+	return fmt.Sprintf("Transfers or no-op contract calls carrying large calldata. Options: size=payload bytes, fixed or min:max for uniformly "+
+		"distributed sizes, up to %d (default 1024), target=transfer|contract (default transfer), "+
+		"fill=random|zero, random bytes cost 16 gas each, zeros 4 (default random)", calldataMaxSize)
+}
+
+func (w *calldataWorkload) Validate(params Params) error {
+	err := params.Check("size", "target", "fill")
+	if err != nil {
+		return err
+	}
+
+	size := params.String("size", "1024")
+	low, high, ranged := strings.Cut(size, ":")
+	w.minSize, err = strconv.Atoi(low)
+	if err != nil {
+		return fmt.Errorf("size must be bytes or min:max, got %q", size)
+	}
+	w.maxSize = w.minSize
+	if ranged {
+		w.maxSize, err = strconv.Atoi(high)
+		if err != nil {
+			return fmt.Errorf("size must be bytes or min:max, got %q", size)
+		}
+	}
+	if w.minSize < 1 || w.maxSize < w.minSize || w.maxSize > calldataMaxSize {
+		return fmt.Errorf("size must be between 1 and %d bytes with min below max, got %q", calldataMaxSize, size)
+	}
+
+	switch w.target = params.String("target", calldataTargetTransfer); w.target {
+	case calldataTargetTransfer, calldataTargetContract:
+	default:
+		return fmt.Errorf("unknown target %q, expected %s or %s", w.target, calldataTargetTransfer, calldataTargetContract)
+	}
+
+	switch w.fill = params.String("fill", calldataFillRandom); w.fill {
+	case calldataFillRandom, calldataFillZero:
+	default:
+		return fmt.Errorf("unknown fill %q, expected %s or %s", w.fill, calldataFillRandom, calldataFillZero)
+	}
+
+	return nil
+}
+
+func (w *calldataWorkload) Prepare(g *Generator) error {
+	if w.target == calldataTargetContract {
+		var err error
+		w.noOp, err = g.deployContract(noOpContractGasLimit, noop.NoOpBin, noop.NoOpABI)
+		if err != nil {
+			return err
+		}
+		fmt.Println("NoOp contract:", w.noOp.Hex())
+	} else {
+		w.value = big.NewInt(10000000000000) // 1/100,000 ETH
+	}
+
+	g.prepareSenders()
+
+	w.minGas = w.estimate(g, w.minSize)
+	w.maxGas = w.estimate(g, w.maxSize)
+	fmt.Printf("Calldata: size %d-%d bytes target=%s fill=%s, estimated gas: %d-%d\n", w.minSize, w.maxSize, w.target, w.fill, w.minGas, w.maxGas)
+
+	w.senders = make([]*rand.Rand, len(g.Senders))
+	for i := range w.senders {
+		w.senders[i] = rand.New(rand.NewSource(int64(i)))
+	}
+
+	return nil
+}
+
+func (w *calldataWorkload) GenerateTx(g *Generator, senderIndex int, sender *account.Account, seq int) (*types.Transaction, error) {
+	size := w.minSize + w.senders[senderIndex].Intn(w.maxSize-w.minSize+1)
+	data := w.payload(crypto.Keccak256(sender.Address.Bytes(), big.NewInt(int64(seq)).Bytes()), size)
+	to, value := w.to(g, seq)
+
+	tx := NewTypedTx(g.TxType(), g.ChainID, sender.GetNonce(), &to, value, w.gasLimit(size), g.Fees, data)
+	return types.SignTx(tx, types.LatestSignerForChainID(g.ChainID), sender.PrivateKey)
+}
+
+// payload is size bytes of the fill, random ones never being zero so that the
+// gas of a payload only depends on its size.
+func (w *calldataWorkload) payload(seed []byte, size int) []byte {
+	if w.fill == calldataFillZero {
+		return make([]byte, size)
+	}
+	return seedBytes(seed, size)
+}
+
+func (w *calldataWorkload) to(g *Generator, seq int) (common.Address, *big.Int) {
+	if w.target == calldataTargetContract {
+		return w.noOp, big.NewInt(0)
+	}
+	return common.HexToAddress(g.Recipients[seq]), w.value
+}
+
+// estimate asks the node for the gas of a payload rather than adding up the
+// calldata costs, as which of the standard cost and the floor of EIP-7623 is
+// charged depends on the fork. It uses the faucet, it is funded already,
+// unlike the fresh senders.
+func (w *calldataWorkload) estimate(g *Generator, size int) uint64 {
+	to, value := w.to(g, 0)
+	return g.estimateGas(ethereum.CallMsg{
+		From:  g.FaucetAccount.Address,
+		To:    &to,
+		Value: value,
+		Data:  w.payload(nil, size),
+	})
+}
+
+// gasLimit interpolates the estimates of the smallest and largest payloads.
+// Both the standard cost and the floor grow linearly with the size and the
+// larger of them is charged, so the line between the estimates is exact at its
+// ends and above the gas of every size in between.
+func (w *calldataWorkload) gasLimit(size int) uint64 {
+	if w.maxSize == w.minSize {
+		return w.minGas
+	}
+	span := uint64(w.maxSize - w.minSize)
+	return w.minGas + ((w.maxGas-w.minGas)*uint64(size-w.minSize)+span-1)/span
+}
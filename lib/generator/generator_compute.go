@@ -0,0 +1,182 @@
+package generator
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/0glabs/evmchainbench/lib/account"
+	"github.com/0glabs/evmchainbench/lib/contract_meta_data/computebench"
+)
+
+func init() {
+	Register(func() Workload { return &computeWorkload{} })
+}
+
+const (
+	computeOpKeccak = "keccak"
+	computeOpArith  = "arith"
+	computeOpMemory = "memory"
+	computeOpMix    = "mix"
+
+	// rounds of the second call used to measure the gas of one round
+	computeCalibrationRounds = 100
+)
+
+var computeOps = []string{computeOpKeccak, computeOpArith, computeOpMemory}
+
+// computeWorkload calls loops of a single class of opcodes that never touch
+// state. The number of rounds is calibrated with eth_estimateGas so that every
+// transaction uses about gas-per-tx gas.
+type computeWorkload struct {
+	ops      []string
+	gasPerTx uint64
+	memWords int
+
+	contractAddress common.Address
+	rounds          map[string]uint64
+	estimateGas     map[string]uint64
+
+	mutex  sync.Mutex
+	labels map[common.Hash]string
+}
+
+func (w *computeWorkload) Name() string {
+	return "compute"
+}
+
+func (w *computeWorkload) Describe() string {
+	return "Compute bound calls without state access. Options: op=keccak|arith|memory|mix (default mix, round robin over the others), " +
+		"gas-per-tx=target gas of each tx (default 1000000), mem-words=memory size of op=memory in words (default 1024)"
+}
+
+func (w *computeWorkload) Validate(params Params) error {
+	err := params.Check("op", "gas-per-tx", "mem-words")
+	if err != nil {
+		return err
+	}
+
+	switch op := params.String("op", computeOpMix); op {
+	case computeOpKeccak, computeOpArith, computeOpMemory:
+		w.ops = []string{op}
+	case computeOpMix:
+		w.ops = computeOps
+	default:
+		return fmt.Errorf("unknown op %q, expected %s, %s, %s or %s", op, computeOpKeccak, computeOpArith, computeOpMemory, computeOpMix)
+	}
+
+	gasPerTx, err := params.Int("gas-per-tx", 1000000)
+	if err != nil {
+		return err
+	}
+	if gasPerTx < 100000 {
+		return fmt.Errorf("gas-per-tx must be at least 100000")
+	}
+	w.gasPerTx = uint64(gasPerTx)
+
+	w.memWords, err = params.Int("mem-words", 1024)
+	if err != nil {
+		return err
+	}
+	if w.memWords < 1 {
+		return fmt.Errorf("mem-words must be at least 1")
+	}
+
+	return nil
+}
+
+func (w *computeWorkload) Prepare(g *Generator) error {
+	var err error
+	w.contractAddress, err = g.deployContract(computeBenchContractGasLimit, computebench.ComputeBenchBin, computebench.ComputeBenchABI)
+	if err != nil {
+		return err
+	}
+	fmt.Println("ComputeBench contract:", w.contractAddress.Hex())
+
+	g.prepareSenders()
+
+	w.rounds = make(map[string]uint64)
+	w.estimateGas = make(map[string]uint64)
+	for _, op := range w.ops {
+		err = w.calibrate(g, op)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Compute %s: %d rounds, estimated gas: %d\n", op, w.rounds[op], w.estimateGas[op])
+	}
+
+	w.labels = make(map[common.Hash]string)
+
+	return nil
+}
+
+// calibrate assumes the gas of a call grows linearly with the rounds, which
+// holds as all ops work on a fixed amount of memory. The call at the chosen
+// rounds is estimated once more to get the actual gas limit.
+func (w *computeWorkload) calibrate(g *Generator, op string) error {
+	base := w.estimate(g, op, 0)
+	if base >= w.gasPerTx {
+		return fmt.Errorf("gas-per-tx %d is below the %d gas of an empty %s call", w.gasPerTx, base, op)
+	}
+	perRound := (w.estimate(g, op, computeCalibrationRounds) - base) / computeCalibrationRounds
+	if perRound == 0 {
+		return fmt.Errorf("failed to measure the gas of a %s round", op)
+	}
+
+	w.rounds[op] = (w.gasPerTx - base) / perRound
+	w.estimateGas[op] = w.estimate(g, op, w.rounds[op])
+
+	return nil
+}
+
+// estimate uses the faucet, it is funded already, unlike the fresh senders
+func (w *computeWorkload) estimate(g *Generator, op string, rounds uint64) uint64 {
+	faucet := g.FaucetAccount
+	tx := GenerateContractCallingTx(faucet.PrivateKey, w.contractAddress.Hex(), 0, g.TxType(), g.ChainID, g.Fees,
+		2*w.gasPerTx, computebench.ComputeBenchABI, w.method(op), w.args(op, rounds)...)
+	return g.estimateGas(ConvertLegacyTxToCallMsg(tx, faucet.Address))
+}
+
+func (w *computeWorkload) method(op string) string {
+	return op + "Loop"
+}
+
+func (w *computeWorkload) args(op string, rounds uint64) []interface{} {
+	if op == computeOpMemory {
+		return []interface{}{big.NewInt(int64(w.memWords)), new(big.Int).SetUint64(rounds)}
+	}
+	return []interface{}{new(big.Int).SetUint64(rounds)}
+}
+
+func (w *computeWorkload) GenerateTx(g *Generator, senderIndex int, sender *account.Account, seq int) (*types.Transaction, error) {
+	// senders start at different ops so that every block gets a mix
+	op := w.ops[(senderIndex+seq)%len(w.ops)]
+
+	tx := GenerateContractCallingTx(
+		sender.PrivateKey,
+		w.contractAddress.Hex(),
+		sender.GetNonce(),
+		g.TxType(),
+		g.ChainID,
+		g.Fees,
+		w.estimateGas[op],
+		computebench.ComputeBenchABI,
+		w.method(op),
+		w.args(op, w.rounds[op])...,
+	)
+
+	if len(w.ops) > 1 {
+		w.mutex.Lock()
+		w.labels[tx.Hash()] = op
+		w.mutex.Unlock()
+	}
+
+	return tx, nil
+}
+
+func (w *computeWorkload) Labels() map[common.Hash]string {
+	return w.labels
+}
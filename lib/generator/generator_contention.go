@@ -0,0 +1,251 @@
+package generator
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/0glabs/evmchainbench/lib/account"
+	"github.com/0glabs/evmchainbench/lib/contract_meta_data/contention"
+	"github.com/0glabs/evmchainbench/lib/contract_meta_data/erc20"
+)
+
+func init() {
+	Register(func() Workload { return &contentionWorkload{} })
+}
+
+const (
+	contentionTargetSlot   = "slot"
+	contentionTargetERC20  = "erc20"
+	contentionTargetNative = "native"
+
+	contentionDistUniform = "uniform"
+	contentionDistZipf    = "zipf"
+	contentionDistSingle  = "single"
+
+	contentionLabelHot  = "hot"
+	contentionLabelCold = "cold"
+)
+
+// contentionWorkload makes a configurable share of the transactions touch a
+// small set of hot keys, the rest touch keys no other transaction uses. With
+// Block-STM every transaction sharing a hot key with a concurrently executed
+// one is a conflict, so the knobs directly control the conflict rate.
+type contentionWorkload struct {
+	target   string
+	dist     string
+	hotKeys  int
+	hotRatio float64
+	zipfS    float64
+
+	contractAddress common.Address
+	hotRecipients   []common.Address
+	estimateGas     uint64
+
+	rngs  []*rand.Rand
+	zipfs []*rand.Zipf
+
+	mutex  sync.Mutex
+	labels map[common.Hash]string
+}
+
+func (w *contentionWorkload) Name() string {
+	return "contention"
+}
+
+func (w *contentionWorkload) Describe() string {
+	return "State contention with an explicit conflict knob. Options: target=slot|erc20|native (default slot), " +
+		"hot-ratio=share of txs on the hot set (default 1), hot-keys=size of the hot set (default 16), " +
+		"dist=uniform|zipf|single (default uniform), zipf-s=Zipf exponent > 1 (default 1.2)"
+}
+
+func (w *contentionWorkload) Validate(params Params) error {
+	err := params.Check("target", "hot-ratio", "hot-keys", "dist", "zipf-s")
+	if err != nil {
+		return err
+	}
+
+	w.target = params.String("target", contentionTargetSlot)
+	switch w.target {
+	case contentionTargetSlot, contentionTargetERC20, contentionTargetNative:
+	default:
+		return fmt.Errorf("unknown target %q, expected %s, %s or %s", w.target, contentionTargetSlot, contentionTargetERC20, contentionTargetNative)
+	}
+
+	w.dist = params.String("dist", contentionDistUniform)
+	switch w.dist {
+	case contentionDistUniform, contentionDistZipf, contentionDistSingle:
+	default:
+		return fmt.Errorf("unknown dist %q, expected %s, %s or %s", w.dist, contentionDistUniform, contentionDistZipf, contentionDistSingle)
+	}
+
+	w.hotKeys, err = params.Int("hot-keys", 16)
+	if err != nil {
+		return err
+	}
+	if w.hotKeys < 1 {
+		return fmt.Errorf("hot-keys must be at least 1")
+	}
+	if w.dist == contentionDistSingle {
+		w.hotKeys = 1
+	}
+
+	w.hotRatio, err = params.Float("hot-ratio", 1)
+	if err != nil {
+		return err
+	}
+	if w.hotRatio < 0 || w.hotRatio > 1 {
+		return fmt.Errorf("hot-ratio must be between 0 and 1")
+	}
+
+	w.zipfS, err = params.Float("zipf-s", 1.2)
+	if err != nil {
+		return err
+	}
+	if w.zipfS <= 1 {
+		return fmt.Errorf("zipf-s must be greater than 1")
+	}
+
+	return nil
+}
+
+func (w *contentionWorkload) Prepare(g *Generator) error {
+	fmt.Printf("Contention: target=%s, %.0f%% of txs on %d hot keys (%s)\n", w.target, w.hotRatio*100, w.hotKeys, w.dist)
+
+	w.hotRecipients = make([]common.Address, w.hotKeys)
+	for i := range w.hotRecipients {
+		r, err := account.GenerateRandomAddress()
+		if err != nil {
+			return err
+		}
+		w.hotRecipients[i] = common.HexToAddress(r)
+	}
+
+	var tx *types.Transaction
+	var err error
+	faucet := g.FaucetAccount
+	switch w.target {
+	case contentionTargetSlot:
+		w.contractAddress, err = g.deployContract(contentionContractGasLimit, contention.ContentionBin, contention.ContentionABI)
+		if err != nil {
+			return err
+		}
+		fmt.Println("Contention contract:", w.contractAddress.Hex())
+
+		g.prepareSenders()
+
+		// touching a fresh key is the most expensive case
+		tx = GenerateContractCallingTx(faucet.PrivateKey, w.contractAddress.Hex(), 0, g.TxType(), g.ChainID, g.Fees,
+			contentionTouchGasLimit, contention.ContentionABI, "touch", big.NewInt(0))
+	case contentionTargetERC20:
+		w.contractAddress, err = g.prepareContractERC20()
+		if err != nil {
+			return err
+		}
+		fmt.Println("ERC20 contract:", w.contractAddress.Hex())
+
+		g.prepareSenders()
+		g.prepareERC20(w.contractAddress.Hex())
+
+		tx = GenerateContractCallingTx(faucet.PrivateKey, w.contractAddress.Hex(), 0, g.TxType(), g.ChainID, g.Fees,
+			erc20TransferGasLimit, erc20.MyTokenABI, "transfer", w.hotRecipients[0], big.NewInt(1000))
+	case contentionTargetNative:
+		g.prepareSenders()
+	}
+
+	if tx != nil {
+		w.estimateGas = g.estimateGas(ConvertLegacyTxToCallMsg(tx, faucet.Address))
+		fmt.Println("Estimated gas:", w.estimateGas)
+	}
+
+	w.rngs = make([]*rand.Rand, len(g.Senders))
+	w.zipfs = make([]*rand.Zipf, len(g.Senders))
+	for i := range g.Senders {
+		w.rngs[i] = rand.New(rand.NewSource(int64(i)))
+		w.zipfs[i] = rand.NewZipf(w.rngs[i], w.zipfS, 1, uint64(w.hotKeys-1))
+	}
+	w.labels = make(map[common.Hash]string)
+
+	return nil
+}
+
+func (w *contentionWorkload) GenerateTx(g *Generator, senderIndex int, sender *account.Account, seq int) (*types.Transaction, error) {
+	hotKey, hot := w.pickHotKey(senderIndex)
+
+	var tx *types.Transaction
+	var err error
+	switch w.target {
+	case contentionTargetSlot:
+		// cold keys are above the hot range and unique per sender and seq
+		key := uint64(hotKey)
+		if !hot {
+			key = uint64(w.hotKeys) + uint64(senderIndex)*uint64(len(g.Recipients)) + uint64(seq)
+		}
+		tx = GenerateContractCallingTx(sender.PrivateKey, w.contractAddress.Hex(), sender.GetNonce(), g.TxType(), g.ChainID, g.Fees,
+			w.estimateGas, contention.ContentionABI, "touch", new(big.Int).SetUint64(key))
+	case contentionTargetERC20:
+		tx = GenerateContractCallingTx(sender.PrivateKey, w.contractAddress.Hex(), sender.GetNonce(), g.TxType(), g.ChainID, g.Fees,
+			w.estimateGas, erc20.MyTokenABI, "transfer", w.recipient(sender, seq, hotKey, hot), big.NewInt(1000))
+	case contentionTargetNative:
+		tx, err = GenerateSimpleTransferTx(sender.PrivateKey, w.recipient(sender, seq, hotKey, hot).Hex(), sender.GetNonce(), g.TxType(), g.ChainID, g.Fees,
+			big.NewInt(10000000000000))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	label := contentionLabelCold
+	if hot {
+		label = contentionLabelHot
+	}
+	w.mutex.Lock()
+	w.labels[tx.Hash()] = label
+	w.mutex.Unlock()
+
+	return tx, nil
+}
+
+func (w *contentionWorkload) AccessList(tx *types.Transaction, from common.Address) types.AccessList {
+	switch w.target {
+	case contentionTargetSlot:
+		return types.AccessList{{Address: *tx.To(), StorageKeys: []common.Hash{mappingSlot(callArg(tx, 0), 0)}}}
+	case contentionTargetERC20:
+		return erc20TransferAccessList(tx, from)
+	default:
+		return nil
+	}
+}
+
+func (w *contentionWorkload) Labels() map[common.Hash]string {
+	return w.labels
+}
+
+func (w *contentionWorkload) pickHotKey(senderIndex int) (int, bool) {
+	rng := w.rngs[senderIndex]
+	if rng.Float64() >= w.hotRatio {
+		return 0, false
+	}
+
+	switch w.dist {
+	case contentionDistZipf:
+		return int(w.zipfs[senderIndex].Uint64()), true
+	case contentionDistUniform:
+		return rng.Intn(w.hotKeys), true
+	default:
+		return 0, true
+	}
+}
+
+// recipient returns the hot recipient, or an address derived from the sender
+// and seq so that cold transfers never share a recipient.
+func (w *contentionWorkload) recipient(sender *account.Account, seq, hotKey int, hot bool) common.Address {
+	if hot {
+		return w.hotRecipients[hotKey]
+	}
+	return common.BytesToAddress(crypto.Keccak256(sender.Address.Bytes(), big.NewInt(int64(seq)).Bytes()))
+}
@@ -0,0 +1,110 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/0glabs/evmchainbench/lib/account"
+	"github.com/0glabs/evmchainbench/lib/contract_meta_data/counter"
+)
+
+func init() {
+	Register(func() Workload { return &counterWorkload{} })
+}
+
+const (
+	counterModeShared    = "shared"
+	counterModePerSender = "per-sender"
+)
+
+type counterWorkload struct {
+	method          string
+	contractAddress common.Address
+	estimateGas     uint64
+}
+
+func (w *counterWorkload) Name() string {
+	return "counter"
+}
+
+func (w *counterWorkload) Describe() string {
+	return "Counter contract increments, the cheapest contract call. " +
+		"Options: mode=shared (every sender bumps one slot, default) or mode=per-sender (one slot per sender)"
+}
+
+func (w *counterWorkload) Validate(params Params) error {
+	err := params.Check("mode")
+	if err != nil {
+		return err
+	}
+
+	switch mode := params.String("mode", counterModeShared); mode {
+	case counterModeShared:
+		w.method = "increment"
+	case counterModePerSender:
+		w.method = "incrementSender"
+	default:
+		return fmt.Errorf("unknown mode %q, expected %s or %s", mode, counterModeShared, counterModePerSender)
+	}
+
+	return nil
+}
+
+func (w *counterWorkload) Prepare(g *Generator) error {
+	contractAddress, err := g.deployContract(counterContractGasLimit, counter.CounterBin, counter.CounterABI)
+	if err != nil {
+		return err
+	}
+	w.contractAddress = contractAddress
+	fmt.Println("Counter contract:", contractAddress.Hex())
+
+	g.prepareSenders()
+
+	// The first increment of a slot is the most expensive one, so the
+	// estimation taken before any increment is a safe limit for all of them.
+	// The faucet is used as it is funded already, unlike the fresh senders.
+	sender := g.FaucetAccount
+	tx := GenerateContractCallingTx(
+		sender.PrivateKey,
+		contractAddress.Hex(),
+		0,
+		g.TxType(),
+		g.ChainID,
+		g.Fees,
+		counterIncrementGasLimit,
+		counter.CounterABI,
+		w.method,
+	)
+	w.estimateGas = g.estimateGas(ConvertLegacyTxToCallMsg(tx, sender.Address))
+
+	fmt.Println("Estimated gas:", w.estimateGas)
+
+	return nil
+}
+
+func (w *counterWorkload) GenerateTx(g *Generator, senderIndex int, sender *account.Account, seq int) (*types.Transaction, error) {
+	tx := GenerateContractCallingTx(
+		sender.PrivateKey,
+		w.contractAddress.Hex(),
+		sender.GetNonce(),
+		g.TxType(),
+		g.ChainID,
+		g.Fees,
+		w.estimateGas,
+		counter.CounterABI,
+		w.method,
+	)
+	return tx, nil
+}
+
+// AccessList knows the layout of the contract: count at slot 0 and the counts
+// of the senders in the mapping at slot 1.
+func (w *counterWorkload) AccessList(tx *types.Transaction, from common.Address) types.AccessList {
+	slot := common.Hash{}
+	if w.method == "incrementSender" {
+		slot = mappingSlot(common.BytesToHash(from.Bytes()), 1)
+	}
+	return types.AccessList{{Address: *tx.To(), StorageKeys: []common.Hash{slot}}}
+}
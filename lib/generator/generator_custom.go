@@ -0,0 +1,438 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/0glabs/evmchainbench/lib/account"
+)
+
+func init() {
+	Register(func() Workload { return &customWorkload{} })
+}
+
+const (
+	customVarSender      = "sender"
+	customVarSenderIndex = "sender-index"
+	customVarSeq         = "seq"
+	customVarRecipient   = "recipient"
+	customVarRand        = "rand"
+)
+
+// customWorkload calls a method of any contract. Every argument is a template
+// in which {sender}, {sender-index}, {seq}, {recipient} and {rand:min:max} are
+// replaced for each transaction before the result is converted to the ABI
+// type of the argument.
+type customWorkload struct {
+	abiJSON  string
+	bin      string
+	method   string
+	args     []customArg
+	ctorArgs []customArg
+	gasLimit uint64
+	value    *big.Int
+
+	contractAddress common.Address
+	estimateGas     uint64
+
+	rngs []*rand.Rand
+}
+
+type customArg struct {
+	typ      abi.Type
+	template string
+}
+
+// customVars are the values of the template variables for one transaction.
+type customVars struct {
+	sender      common.Address
+	senderIndex int
+	seq         int
+	recipient   string
+	rng         *rand.Rand
+}
+
+func (w *customWorkload) Name() string {
+	return "custom"
+}
+
+func (w *customWorkload) Describe() string {
+	return "Calls a method of your own contract. Options: artifact=Hardhat style JSON to deploy, or address=deployed contract " +
+		"with abi=ABI or artifact JSON, method=name, arg0..argN=argument templates, ctor-arg0..ctor-argN=constructor arguments, " +
+		"gas=gas limit (default estimated), value=wei sent with every call of a payable method (default 0). Templates may use {sender}, {sender-index}, {seq}, {recipient} and {rand:min:max}"
+}
+
+func (w *customWorkload) Validate(params Params) error {
+	artifact := params.String("artifact", "")
+	address := params.String("address", "")
+	abiFile := params.String("abi", "")
+
+	switch {
+	case address != "":
+		if !common.IsHexAddress(address) {
+			return fmt.Errorf("invalid address %q", address)
+		}
+		w.contractAddress = common.HexToAddress(address)
+		if abiFile == "" {
+			abiFile = artifact
+		}
+		if abiFile == "" {
+			return fmt.Errorf("address needs abi or artifact")
+		}
+		abiJSON, err := customReadABI(abiFile)
+		if err != nil {
+			return err
+		}
+		w.abiJSON = abiJSON
+	case artifact != "":
+		if abiFile != "" {
+			return fmt.Errorf("abi is only used with address, the artifact has its own")
+		}
+		var err error
+		w.abiJSON, w.bin, err = readContract(artifact)
+		if err != nil {
+			return err
+		}
+		w.bin = strings.TrimPrefix(w.bin, "0x")
+		if w.bin == "" {
+			return fmt.Errorf("artifact %s has no bytecode", artifact)
+		}
+	default:
+		return fmt.Errorf("either artifact or address is needed")
+	}
+
+	contractABI, err := abi.JSON(strings.NewReader(w.abiJSON))
+	if err != nil {
+		return fmt.Errorf("failed to parse ABI: %w", err)
+	}
+
+	w.method = params.String("method", "")
+	method, ok := contractABI.Methods[w.method]
+	if !ok {
+		return fmt.Errorf("method %q not found in the ABI", w.method)
+	}
+
+	known := []string{"artifact", "address", "abi", "method", "gas", "value"}
+	w.args, known, err = customArgs(params, "arg", method.Inputs, known)
+	if err != nil {
+		return err
+	}
+	if w.bin != "" {
+		w.ctorArgs, known, err = customArgs(params, "ctor-arg", contractABI.Constructor.Inputs, known)
+		if err != nil {
+			return err
+		}
+	}
+	err = params.Check(known...)
+	if err != nil {
+		return err
+	}
+
+	gasLimit, err := params.Int("gas", 0)
+	if err != nil {
+		return err
+	}
+	if gasLimit < 0 {
+		return fmt.Errorf("gas must not be negative")
+	}
+	w.gasLimit = uint64(gasLimit)
+
+	value := params.String("value", "0")
+	w.value, ok = new(big.Int).SetString(value, 10)
+	if !ok || w.value.Sign() < 0 {
+		return fmt.Errorf("value must be a non-negative amount of wei, got %q", value)
+	}
+	if w.value.Sign() > 0 && !method.Payable {
+		return fmt.Errorf("method %q is not payable", w.method)
+	}
+
+	// evaluate the templates once so that mistakes show up before any tx is sent
+	vars := customVars{rng: rand.New(rand.NewSource(0))}
+	_, err = w.values(w.args, vars)
+	if err != nil {
+		return err
+	}
+	_, err = w.values(w.ctorArgs, vars)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (w *customWorkload) Prepare(g *Generator) error {
+	if w.bin == "" {
+		err := w.checkCode(g)
+		if err != nil {
+			return err
+		}
+	} else {
+		vars := customVars{sender: g.FaucetAccount.Address, rng: rand.New(rand.NewSource(0))}
+		if len(g.Recipients) > 0 {
+			vars.recipient = g.Recipients[0]
+		}
+		ctorArgs, err := w.values(w.ctorArgs, vars)
+		if err != nil {
+			return err
+		}
+		w.contractAddress, err = g.deployContract(customContractGasLimit, w.bin, w.abiJSON, ctorArgs...)
+		if err != nil {
+			return err
+		}
+	}
+	fmt.Println("Custom contract:", w.contractAddress.Hex(), "method:", w.method)
+
+	g.prepareSenders()
+
+	w.rngs = make([]*rand.Rand, len(g.Senders))
+	for i := range w.rngs {
+		w.rngs[i] = rand.New(rand.NewSource(int64(i)))
+	}
+
+	if w.gasLimit != 0 {
+		w.estimateGas = w.gasLimit
+		return nil
+	}
+
+	sender := g.Senders[0]
+	args, err := w.values(w.args, w.vars(g, 0, sender, 0, rand.New(rand.NewSource(0))))
+	if err != nil {
+		return err
+	}
+	tx := GenerateContractCallingTx(sender.PrivateKey, w.contractAddress.Hex(), 0, g.TxType(), g.ChainID, g.Fees,
+		customCallGasLimit, w.abiJSON, w.method, args...)
+	msg := ConvertLegacyTxToCallMsg(tx, sender.Address)
+	// without a price and a value the estimation does not depend on the sender
+	// being funded yet, the value rarely changes the gas
+	msg.GasPrice = nil
+	gas, err := g.tryEstimateGas(msg)
+	if err != nil {
+		return fmt.Errorf("failed to estimate gas of %s, set it with the gas option: %w", w.method, err)
+	}
+	// the arguments differ from tx to tx, so does the gas
+	w.estimateGas = (uint64)(1.2 * float64(gas))
+
+	fmt.Println("Estimated gas:", w.estimateGas)
+
+	return nil
+}
+
+func (w *customWorkload) GenerateTx(g *Generator, senderIndex int, sender *account.Account, seq int) (*types.Transaction, error) {
+	args, err := w.values(w.args, w.vars(g, senderIndex, sender, seq, w.rngs[senderIndex]))
+	if err != nil {
+		return nil, err
+	}
+
+	tx := GeneratePayableContractCallingTx(
+		sender.PrivateKey,
+		w.contractAddress.Hex(),
+		sender.GetNonce(),
+		g.TxType(),
+		g.ChainID,
+		g.Fees,
+		w.estimateGas,
+		w.value,
+		w.abiJSON,
+		w.method,
+		args...,
+	)
+	return tx, nil
+}
+
+func (w *customWorkload) vars(g *Generator, senderIndex int, sender *account.Account, seq int, rng *rand.Rand) customVars {
+	return customVars{
+		sender:      sender.Address,
+		senderIndex: senderIndex,
+		seq:         seq,
+		recipient:   g.Recipients[seq],
+		rng:         rng,
+	}
+}
+
+func (w *customWorkload) values(args []customArg, vars customVars) ([]interface{}, error) {
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		s, err := customExpand(arg.template, vars)
+		if err != nil {
+			return nil, err
+		}
+		values[i], err = customConvert(arg.typ, s)
+		if err != nil {
+			return nil, fmt.Errorf("argument %d (%s) %q: %w", i, arg.typ.String(), s, err)
+		}
+	}
+	return values, nil
+}
+
+// customArgs reads the templates <prefix>0..<prefix>N of the given inputs and
+// adds their keys to the known parameters.
+func customArgs(params Params, prefix string, inputs abi.Arguments, known []string) ([]customArg, []string, error) {
+	args := make([]customArg, len(inputs))
+	for i, input := range inputs {
+		key := fmt.Sprintf("%s%d", prefix, i)
+		template, ok := params[key]
+		if !ok {
+			return nil, nil, fmt.Errorf("missing %s for %s %s", key, input.Type.String(), input.Name)
+		}
+		args[i] = customArg{typ: input.Type, template: template}
+		known = append(known, key)
+	}
+	return args, known, nil
+}
+
+// checkCode makes sure that the given address holds a contract, calls of an
+// account without code succeed without doing anything.
+func (w *customWorkload) checkCode(g *Generator) error {
+	client, err := ethclient.Dial(g.RpcUrl)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	code, err := client.CodeAt(context.Background(), w.contractAddress, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get the code at %s: %w", w.contractAddress.Hex(), err)
+	}
+	if len(code) == 0 {
+		return fmt.Errorf("there is no contract at %s", w.contractAddress.Hex())
+	}
+	return nil
+}
+
+// customReadABI accepts both a bare ABI and an artifact containing one.
+func customReadABI(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "[") {
+		return string(data), nil
+	}
+	abiJSON, _, err := readContract(path)
+	return abiJSON, err
+}
+
+func customExpand(template string, vars customVars) (string, error) {
+	var sb strings.Builder
+	for {
+		start := strings.Index(template, "{")
+		if start < 0 {
+			sb.WriteString(template)
+			return sb.String(), nil
+		}
+		end := strings.Index(template[start:], "}")
+		if end < 0 {
+			return "", fmt.Errorf("unterminated variable in %q", template)
+		}
+		sb.WriteString(template[:start])
+
+		name := template[start+1 : start+end]
+		switch {
+		case name == customVarSender:
+			sb.WriteString(vars.sender.Hex())
+		case name == customVarSenderIndex:
+			sb.WriteString(strconv.Itoa(vars.senderIndex))
+		case name == customVarSeq:
+			sb.WriteString(strconv.Itoa(vars.seq))
+		case name == customVarRecipient:
+			sb.WriteString(common.HexToAddress(vars.recipient).Hex())
+		case strings.HasPrefix(name, customVarRand+":"):
+			n, err := customRand(strings.TrimPrefix(name, customVarRand+":"), vars.rng)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(strconv.FormatInt(n, 10))
+		default:
+			return "", fmt.Errorf("unknown variable {%s}", name)
+		}
+
+		template = template[start+end+1:]
+	}
+}
+
+// customRand returns a random integer of the inclusive range "min:max".
+func customRand(bounds string, rng *rand.Rand) (int64, error) {
+	lowStr, highStr, ok := strings.Cut(bounds, ":")
+	if !ok {
+		return 0, fmt.Errorf("expected {%s:min:max}, got {%s:%s}", customVarRand, customVarRand, bounds)
+	}
+	low, err := strconv.ParseInt(lowStr, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	high, err := strconv.ParseInt(highStr, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if high < low {
+		return 0, fmt.Errorf("empty range {%s:%s}", customVarRand, bounds)
+	}
+	return low + rng.Int63n(high-low+1), nil
+}
+
+// customConvert turns the expanded template into the Go type abi.Pack expects
+// for the given ABI type.
+func customConvert(typ abi.Type, s string) (interface{}, error) {
+	switch typ.T {
+	case abi.IntTy, abi.UintTy:
+		n, ok := new(big.Int).SetString(s, 0)
+		if !ok {
+			return nil, fmt.Errorf("not an integer")
+		}
+		low, high := new(big.Int), new(big.Int).Lsh(big.NewInt(1), uint(typ.Size))
+		if typ.T == abi.IntTy {
+			high.Rsh(high, 1)
+			low.Neg(high)
+		}
+		if n.Cmp(low) < 0 || n.Cmp(high) >= 0 {
+			return nil, fmt.Errorf("out of range")
+		}
+		goType := typ.GetType()
+		if goType == reflect.TypeOf(&big.Int{}) {
+			return n, nil
+		}
+		v := reflect.New(goType).Elem()
+		if typ.T == abi.UintTy {
+			v.SetUint(n.Uint64())
+		} else {
+			v.SetInt(n.Int64())
+		}
+		return v.Interface(), nil
+	case abi.BoolTy:
+		return strconv.ParseBool(s)
+	case abi.StringTy:
+		return s, nil
+	case abi.AddressTy:
+		if !common.IsHexAddress(s) {
+			return nil, fmt.Errorf("not an address")
+		}
+		return common.HexToAddress(s), nil
+	case abi.BytesTy:
+		return hexutil.Decode(s)
+	case abi.FixedBytesTy:
+		b, err := hexutil.Decode(s)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) > typ.Size {
+			return nil, fmt.Errorf("longer than %d bytes", typ.Size)
+		}
+		v := reflect.New(typ.GetType()).Elem()
+		reflect.Copy(v, reflect.ValueOf(b))
+		return v.Interface(), nil
+	}
+
+	return nil, fmt.Errorf("arguments of type %s are not supported", typ.String())
+}
@@ -0,0 +1,195 @@
+package generator
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/0glabs/evmchainbench/lib/account"
+	"github.com/0glabs/evmchainbench/lib/contract_meta_data/create2factory"
+)
+
+func init() {
+	Register(func() Workload { return &deployWorkload{} })
+}
+
+const (
+	deployModeCreate  = "create"
+	deployModeCreate2 = "create2"
+
+	// EIP-170
+	deployMaxCodeSize = 24576
+	// EIP-3860
+	deployMaxInitCodeSize = 2 * deployMaxCodeSize
+
+	// a tx creating a contract, and the factory call of CREATE2 on top
+	deployBaseGas = 21000 + 32000 + 50000
+	// a cold SSTORE of a fresh slot and the pushes of its key and value
+	deployWriteGas = 22100 + 6
+	// the deposit of a byte of runtime code
+	deployCodeByteGas = 200
+
+	// the opcodes of the generated code
+	opStop     = 0x00
+	opCodecopy = 0x39
+	opSstore   = 0x55
+	opPush1    = 0x60
+	opPush2    = 0x61
+	opDup1     = 0x80
+	opReturn   = 0xf3
+)
+
+// deployWorkload makes every transaction deploy a new contract, either
+// directly or through a CREATE2 factory. The init code writes the first
+// `writes` storage slots and returns `code-size` bytes of runtime code.
+type deployWorkload struct {
+	mode     string
+	codeSize int
+	writes   int
+	unique   bool
+
+	factoryAddress common.Address
+	estimateGas    uint64
+}
+
+func (w *deployWorkload) Name() string {
+	return "deploy"
+}
+
+func (w *deployWorkload) Describe() string {
+	return "Contract deployments by every sender. Options: mode=create|create2 (default create, create2 goes through a factory), " +
+		"code-size=runtime code bytes up to 24576 (default 1024), writes=storage slots written by the constructor (default 0), " +
+		"unique=false to deploy the same code from every tx (default true)"
+}
+
+func (w *deployWorkload) Validate(params Params) error {
+	err := params.Check("mode", "code-size", "writes", "unique")
+	if err != nil {
+		return err
+	}
+
+	w.mode = params.String("mode", deployModeCreate)
+	switch w.mode {
+	case deployModeCreate, deployModeCreate2:
+	default:
+		return fmt.Errorf("unknown mode %q, expected %s or %s", w.mode, deployModeCreate, deployModeCreate2)
+	}
+
+	w.codeSize, err = params.Int("code-size", 1024)
+	if err != nil {
+		return err
+	}
+	if w.codeSize < 1 || w.codeSize > deployMaxCodeSize {
+		return fmt.Errorf("code-size must be between 1 and %d", deployMaxCodeSize)
+	}
+
+	w.writes, err = params.Int("writes", 0)
+	if err != nil {
+		return err
+	}
+	if w.writes < 0 {
+		return fmt.Errorf("writes must not be negative")
+	}
+	if size := w.initCodeSize(); size > deployMaxInitCodeSize {
+		return fmt.Errorf("writes=%d and code-size=%d make %d bytes of init code, more than the %d allowed", w.writes, w.codeSize, size, deployMaxInitCodeSize)
+	}
+	if gas := w.gasBound(); gas > deployGasLimit {
+		return fmt.Errorf("writes=%d and code-size=%d may use %d gas, more than the %d gas limit of a deployment", w.writes, w.codeSize, gas, deployGasLimit)
+	}
+
+	w.unique, err = params.Bool("unique", true)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (w *deployWorkload) Prepare(g *Generator) error {
+	fmt.Printf("Deploy: mode=%s code-size=%d writes=%d unique=%t\n", w.mode, w.codeSize, w.writes, w.unique)
+
+	if w.mode == deployModeCreate2 {
+		var err error
+		w.factoryAddress, err = g.deployContract(create2FactoryContractGasLimit, create2factory.Create2FactoryBin, create2factory.Create2FactoryABI)
+		if err != nil {
+			return err
+		}
+		fmt.Println("Create2Factory contract:", w.factoryAddress.Hex())
+	}
+
+	g.prepareSenders()
+
+	// every tx has the same cost as the generated bytes are never zero, the
+	// faucet is used as it is funded already, unlike the fresh senders
+	faucet := g.FaucetAccount
+	tx, err := w.deployTx(g, faucet, 0, 0, deployGasLimit)
+	if err != nil {
+		return err
+	}
+	w.estimateGas = g.estimateGas(ConvertLegacyTxToCallMsg(tx, faucet.Address))
+
+	fmt.Println("Estimated gas:", w.estimateGas)
+
+	return nil
+}
+
+func (w *deployWorkload) GenerateTx(g *Generator, senderIndex int, sender *account.Account, seq int) (*types.Transaction, error) {
+	return w.deployTx(g, sender, sender.GetNonce(), seq, w.estimateGas)
+}
+
+func (w *deployWorkload) deployTx(g *Generator, sender *account.Account, nonce uint64, seq int, gasLimit uint64) (*types.Transaction, error) {
+	seed := []byte("evmchainbench")
+	if w.unique {
+		seed = crypto.Keccak256(sender.Address.Bytes(), big.NewInt(int64(seq)).Bytes())
+	}
+	// the leading STOP keeps the code clear of the EIP-3541 0xEF prefix
+	runtime := append([]byte{opStop}, seedBytes(seed, w.codeSize-1)...)
+	initCode := w.initCode(runtime)
+
+	if w.mode == deployModeCreate {
+		return GenerateContractCreationTx(sender.PrivateKey, nonce, g.TxType(), g.ChainID, g.Fees, gasLimit, hex.EncodeToString(initCode), "")
+	}
+
+	// a salt unique per sender and seq, so no deployment collides
+	salt := [32]byte{}
+	copy(salt[:], seedBytes(crypto.Keccak256(sender.Address.Bytes(), big.NewInt(int64(seq)).Bytes()), 32))
+	tx := GenerateContractCallingTx(sender.PrivateKey, w.factoryAddress.Hex(), nonce, g.TxType(), g.ChainID, g.Fees, gasLimit,
+		create2factory.Create2FactoryABI, "deploy", salt, initCode)
+	return tx, nil
+}
+
+// initCodeSize is the size of the code returned by initCode, which stays
+// below the 64KB addressable by its PUSH2 offsets.
+func (w *deployWorkload) initCodeSize() int {
+	return w.writes*6 + 13 + w.codeSize
+}
+
+// gasBound returns an upper bound of the gas used by a deployment: the
+// calldata and the hashing of the init code, the slots it writes and the
+// deposit of the runtime code.
+func (w *deployWorkload) gasBound() uint64 {
+	size := uint64(w.initCodeSize())
+	return deployBaseGas + 16*size + 8*((size+31)/32) + deployWriteGas*uint64(w.writes) + deployCodeByteGas*uint64(w.codeSize)
+}
+
+// initCode returns code which writes 1 into slots 0..writes-1 and returns the
+// runtime code appended to it.
+func (w *deployWorkload) initCode(runtime []byte) []byte {
+	code := make([]byte, 0, w.initCodeSize())
+	for i := 0; i < w.writes; i++ {
+		code = append(code, opPush1, 1, opPush2, byte(i>>8), byte(i), opSstore)
+	}
+
+	size, offset := len(runtime), len(code)+13
+	code = append(code,
+		opPush2, byte(size>>8), byte(size), opDup1,
+		opPush2, byte(offset>>8), byte(offset), opPush1, 0, opCodecopy,
+		opPush1, 0, opReturn,
+	)
+
+	return append(code, runtime...)
+}
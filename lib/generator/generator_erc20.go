@@ -3,7 +3,6 @@ package generator
 import (
 	"fmt"
 	"math/big"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -12,87 +11,82 @@ import (
 	"github.com/0glabs/evmchainbench/lib/contract_meta_data/erc20"
 )
 
-func (g *Generator) GenerateERC20() (map[int]types.Transactions, error) {
-	txsMap := make(map[int]types.Transactions)
+func init() {
+	Register(func() Workload { return &erc20Workload{} })
+}
 
-	if g.ShouldPersist {
-		defer g.Store.PersistPrepareTxs()
-	}
+type erc20Workload struct {
+	contractAddressStr string
+	amount             *big.Int
+	estimateGas        uint64
+}
 
+func (w *erc20Workload) Name() string {
+	return "erc20"
+}
+
+func (w *erc20Workload) Describe() string {
+	return "ERC20 token transfers from every sender to random recipients through one deployed token"
+}
+
+func (w *erc20Workload) Validate(params Params) error {
+	return params.Check()
+}
+
+func (w *erc20Workload) Prepare(g *Generator) error {
 	contractAddress, err := g.prepareContractERC20()
 	if err != nil {
-		return txsMap, err
+		return err
 	}
-	contractAddressStr := contractAddress.Hex()
+	w.contractAddressStr = contractAddress.Hex()
 
 	g.prepareSenders()
 
-	g.prepareERC20(contractAddressStr)
+	g.prepareERC20(w.contractAddressStr)
 
-	amount := big.NewInt(1000) // a random small amount
-
-	var mutex sync.Mutex
-	ch := make(chan error)
+	w.amount = big.NewInt(1000) // a random small amount
 
 	sender := g.Senders[0]
 	tx := GenerateContractCallingTx(
 		sender.PrivateKey,
-		contractAddressStr,
+		w.contractAddressStr,
 		1,
+		g.TxType(),
 		g.ChainID,
-		g.GasPrice,
+		g.Fees,
 		erc20TransferGasLimit,
 		erc20.MyTokenABI,
 		"transfer",
 		common.HexToAddress(g.Recipients[0]),
-		amount,
+		w.amount,
 	)
 	ethCallTx := ConvertLegacyTxToCallMsg(tx, sender.Address)
-	estimateGas := g.estimateGas(ethCallTx)
-
-	fmt.Println("Estimated gas:", estimateGas)
-
-	for index, sender := range g.Senders {
-		go func(index int, sender *account.Account) {
-			txs := types.Transactions{}
-			for _, recipient := range g.Recipients {
-				tx := GenerateContractCallingTx(
-					sender.PrivateKey,
-					contractAddressStr,
-					sender.GetNonce(),
-					g.ChainID,
-					g.GasPrice,
-					estimateGas,
-					erc20.MyTokenABI,
-					"transfer",
-					common.HexToAddress(recipient),
-					amount,
-				)
-				txs = append(txs, tx)
-			}
-
-			mutex.Lock()
-			txsMap[index] = txs
-			mutex.Unlock()
-			ch <- nil
-		}(index, sender)
-	}
+	w.estimateGas = g.estimateGas(ethCallTx)
 
-	for i := 0; i < len(g.Senders); i++ {
-		msg := <-ch
-		if msg != nil {
-			return txsMap, msg
-		}
-	}
+	fmt.Println("Estimated gas:", w.estimateGas)
 
-	if g.ShouldPersist {
-		err := g.Store.PersistTxsMap(txsMap)
-		if err != nil {
-			return txsMap, err
-		}
-	}
+	return nil
+}
+
+func (w *erc20Workload) GenerateTx(g *Generator, senderIndex int, sender *account.Account, seq int) (*types.Transaction, error) {
+	tx := GenerateContractCallingTx(
+		sender.PrivateKey,
+		w.contractAddressStr,
+		sender.GetNonce(),
+		g.TxType(),
+		g.ChainID,
+		g.Fees,
+		w.estimateGas,
+		erc20.MyTokenABI,
+		"transfer",
+		common.HexToAddress(g.Recipients[seq]),
+		w.amount,
+	)
+	return tx, nil
+}
 
-	return txsMap, nil
+func (w *erc20Workload) AccessList(tx *types.Transaction, from common.Address) types.AccessList {
+	return erc20TransferAccessList(tx, from)
 }
 
 func (g *Generator) prepareContractERC20() (common.Address, error) {
@@ -0,0 +1,125 @@
+package generator
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/0glabs/evmchainbench/lib/account"
+	"github.com/0glabs/evmchainbench/lib/contract_meta_data/eventbench"
+)
+
+func init() {
+	Register(func() Workload { return &eventsWorkload{} })
+}
+
+// eventsWorkload emits `logs` logs of `topics` topics and `size` bytes of data
+// per transaction. The topics differ from tx to tx, so the log index of the
+// node grows with every log. The listener checks that eth_getLogs returns as
+// many logs per block as the included transactions emitted.
+type eventsWorkload struct {
+	logs   int
+	topics int
+	size   int
+
+	contractAddress common.Address
+	estimateGas     uint64
+}
+
+func (w *eventsWorkload) Name() string {
+	return "events"
+}
+
+func (w *eventsWorkload) Describe() string {
+	return "Calls emitting many logs, checked against eth_getLogs for every block. Options: logs=logs per tx (default 10), " +
+		"topics=topics per log from 0 to 4 (default 2), size=data bytes per log (default 64)"
+}
+
+func (w *eventsWorkload) Validate(params Params) error {
+	err := params.Check("logs", "topics", "size")
+	if err != nil {
+		return err
+	}
+
+	w.logs, err = params.Int("logs", 10)
+	if err != nil {
+		return err
+	}
+	if w.logs < 1 {
+		return fmt.Errorf("logs must be at least 1")
+	}
+
+	w.topics, err = params.Int("topics", 2)
+	if err != nil {
+		return err
+	}
+	if w.topics < 0 || w.topics > 4 {
+		return fmt.Errorf("topics must be between 0 and 4")
+	}
+
+	w.size, err = params.Int("size", 64)
+	if err != nil {
+		return err
+	}
+	if w.size < 0 {
+		return fmt.Errorf("size must not be negative")
+	}
+
+	return nil
+}
+
+func (w *eventsWorkload) Prepare(g *Generator) error {
+	var err error
+	w.contractAddress, err = g.deployContract(eventBenchContractGasLimit, eventbench.EventBenchBin, eventbench.EventBenchABI)
+	if err != nil {
+		return err
+	}
+	fmt.Println("EventBench contract:", w.contractAddress.Hex())
+
+	g.prepareSenders()
+
+	// the gas does not depend on the seed, the faucet is funded already,
+	// unlike the fresh senders
+	faucet := g.FaucetAccount
+	tx := w.tx(g, faucet, 0, big.NewInt(0))
+	msg := ConvertLegacyTxToCallMsg(tx, faucet.Address)
+	// many or large logs may need more than any fixed limit, the node caps
+	// the estimation at the gas limit of the block
+	msg.Gas = 0
+	w.estimateGas = g.estimateGas(msg)
+	fmt.Printf("Events: %d logs of %d topics and %d bytes per tx, estimated gas: %d\n", w.logs, w.topics, w.size, w.estimateGas)
+
+	return nil
+}
+
+func (w *eventsWorkload) tx(g *Generator, sender *account.Account, nonce uint64, seed *big.Int) *types.Transaction {
+	return GenerateContractCallingTx(
+		sender.PrivateKey,
+		w.contractAddress.Hex(),
+		nonce,
+		g.TxType(),
+		g.ChainID,
+		g.Fees,
+		w.estimateGas,
+		eventbench.EventBenchABI,
+		"emitLogs",
+		big.NewInt(int64(w.logs)),
+		big.NewInt(int64(w.topics)),
+		big.NewInt(int64(w.size)),
+		seed,
+	)
+}
+
+func (w *eventsWorkload) GenerateTx(g *Generator, senderIndex int, sender *account.Account, seq int) (*types.Transaction, error) {
+	seed := new(big.Int).SetBytes(crypto.Keccak256(sender.Address.Bytes(), big.NewInt(int64(seq)).Bytes()))
+	// room for the topics of the following logs
+	seed.Rsh(seed, 32)
+	return w.tx(g, sender, sender.GetNonce(), seed), nil
+}
+
+func (w *eventsWorkload) LogsPerTx() int {
+	return w.logs
+}
@@ -0,0 +1,234 @@
+package generator
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strconv"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/0glabs/evmchainbench/lib/account"
+)
+
+func init() {
+	Register(func() Workload { return &mempoolWorkload{} })
+}
+
+const (
+	mempoolInOrder = "inorder"
+	mempoolSwap    = "swap"
+	mempoolGap     = "gap"
+	mempoolReplace = "replace"
+)
+
+var mempoolPatterns = []string{mempoolInOrder, mempoolSwap, mempoolGap, mempoolReplace}
+
+// mempoolWorkload sends transfers in nonce patterns which exercise the
+// queue and the replacement logic of the mempool: nonces swapped in pairs,
+// future nonces sent before the gap below them is filled, and transactions
+// replaced by the same nonce at a bumped price. Every tx is labelled, so the
+// summary tells which versions were included.
+type mempoolWorkload struct {
+	patterns    []string
+	weights     []int
+	totalWeight int
+	gapSize     int
+	bump        int
+
+	value    *big.Int
+	replaced *Fees
+	senders  []*mempoolSender
+
+	mutex  sync.Mutex
+	labels map[common.Hash]string
+}
+
+// mempoolSender holds the txs of the pattern a sender is in the middle of.
+type mempoolSender struct {
+	rng     *rand.Rand
+	planned []mempoolTx
+}
+
+type mempoolTx struct {
+	nonce uint64
+	label string
+	fees  *Fees
+}
+
+func (w *mempoolWorkload) Name() string {
+	return "mempool"
+}
+
+func (w *mempoolWorkload) Describe() string {
+	return "Transfers in nonce patterns mixed by weight, e.g. -w swap=1,replace=2 (default all with equal weight). Patterns: " +
+		"inorder, swap=two nonces sent in reverse order, gap=a future nonce sent before the ones below it, " +
+		"replace=a tx followed by one with the same nonce at a bumped price. Options: gap-size=nonces skipped by gap (default 3), " +
+		"bump=price increase of replacements in percent (default 12, geth rejects less than 10)"
+}
+
+func (w *mempoolWorkload) Validate(params Params) error {
+	var err error
+	w.gapSize, err = params.Int("gap-size", 3)
+	if err != nil {
+		return err
+	}
+	if w.gapSize < 1 {
+		return fmt.Errorf("gap-size must be at least 1")
+	}
+
+	w.bump, err = params.Int("bump", 12)
+	if err != nil {
+		return err
+	}
+	if w.bump < 1 {
+		return fmt.Errorf("bump must be at least 1")
+	}
+
+	weights := make(map[string]int)
+	for key, value := range params {
+		if key == "gap-size" || key == "bump" {
+			continue
+		}
+		if _, ok := w.patternSize(key); !ok {
+			return fmt.Errorf("unknown pattern %q, expected %s, %s, %s or %s", key, mempoolInOrder, mempoolSwap, mempoolGap, mempoolReplace)
+		}
+		weight, err := strconv.Atoi(value)
+		if err != nil || weight <= 0 {
+			return fmt.Errorf("weight of %q must be a positive integer, got %q", key, value)
+		}
+		weights[key] = weight
+	}
+	if len(weights) == 0 {
+		for _, pattern := range mempoolPatterns {
+			weights[pattern] = 1
+		}
+	}
+
+	for _, pattern := range mempoolPatterns {
+		if weight, ok := weights[pattern]; ok {
+			w.patterns = append(w.patterns, pattern)
+			w.weights = append(w.weights, weight)
+			w.totalWeight += weight
+		}
+	}
+
+	return nil
+}
+
+func (w *mempoolWorkload) Prepare(g *Generator) error {
+	// both sign the txs again at their own price, the replaced tx would no
+	// longer bid less than its replacement
+	for _, pattern := range w.patterns {
+		if pattern != mempoolReplace {
+			continue
+		}
+		if g.TipTiers != nil {
+			return fmt.Errorf("the %s pattern cannot be used with tip tiers, pick other patterns, e.g. -w %s=1,%s=1,%s=1", mempoolReplace, mempoolInOrder, mempoolSwap, mempoolGap)
+		}
+		if g.FeeStrategy.Mode == FeeTrack {
+			return fmt.Errorf("the %s pattern cannot be used with the %s fee strategy, pick other patterns, e.g. -w %s=1,%s=1,%s=1", mempoolReplace, FeeTrack, mempoolInOrder, mempoolSwap, mempoolGap)
+		}
+	}
+
+	g.prepareSenders()
+
+	w.value = big.NewInt(10000000000000) // 1/100,000 ETH
+	w.replaced = &Fees{
+		GasPrice:  new(big.Int).Div(new(big.Int).Mul(g.Fees.GasPrice, big.NewInt(100)), big.NewInt(int64(100+w.bump))),
+		GasTipCap: new(big.Int).Div(new(big.Int).Mul(g.Fees.GasTipCap, big.NewInt(100)), big.NewInt(int64(100+w.bump))),
+	}
+	fmt.Printf("Mempool: patterns %v gap-size=%d bump=%d%%, replaced txs bid %s\n", w.patterns, w.gapSize, w.bump, w.replaced)
+
+	w.senders = make([]*mempoolSender, len(g.Senders))
+	for i := range w.senders {
+		w.senders[i] = &mempoolSender{rng: rand.New(rand.NewSource(int64(i)))}
+	}
+	w.labels = make(map[common.Hash]string)
+
+	return nil
+}
+
+func (w *mempoolWorkload) GenerateTx(g *Generator, senderIndex int, sender *account.Account, seq int) (*types.Transaction, error) {
+	s := w.senders[senderIndex]
+	if len(s.planned) == 0 {
+		s.planned = w.plan(g, sender, s.rng, len(g.Recipients)-seq)
+	}
+	next := s.planned[0]
+	s.planned = s.planned[1:]
+
+	tx, err := GenerateSimpleTransferTx(sender.PrivateKey, g.Recipients[seq], next.nonce, g.TxType(), g.ChainID, next.fees, w.value)
+	if err != nil {
+		return nil, err
+	}
+
+	w.mutex.Lock()
+	w.labels[tx.Hash()] = next.label
+	w.mutex.Unlock()
+
+	return tx, nil
+}
+
+// plan picks the next pattern which fits in the remaining txs of the sender
+// and reserves its nonces.
+func (w *mempoolWorkload) plan(g *Generator, sender *account.Account, rng *rand.Rand, remaining int) []mempoolTx {
+	pick := rng.Intn(w.totalWeight)
+	pattern := w.patterns[len(w.patterns)-1]
+	for i, weight := range w.weights {
+		if pick < weight {
+			pattern = w.patterns[i]
+			break
+		}
+		pick -= weight
+	}
+	// a pattern cut short would leave a nonce gap which is never filled
+	if size, _ := w.patternSize(pattern); size > remaining {
+		pattern = mempoolInOrder
+	}
+
+	switch pattern {
+	case mempoolSwap:
+		first, second := sender.GetNonce(), sender.GetNonce()
+		return []mempoolTx{
+			{nonce: second, label: "swap ahead", fees: g.Fees},
+			{nonce: first, label: "swap behind", fees: g.Fees},
+		}
+	case mempoolGap:
+		nonces := make([]uint64, w.gapSize+1)
+		for i := range nonces {
+			nonces[i] = sender.GetNonce()
+		}
+		planned := []mempoolTx{{nonce: nonces[w.gapSize], label: "gap future", fees: g.Fees}}
+		for _, nonce := range nonces[:w.gapSize] {
+			planned = append(planned, mempoolTx{nonce: nonce, label: "gap fill", fees: g.Fees})
+		}
+		return planned
+	case mempoolReplace:
+		// the replacement bids the price of the run, the replaced tx less
+		nonce := sender.GetNonce()
+		return []mempoolTx{
+			{nonce: nonce, label: "replaced", fees: w.replaced},
+			{nonce: nonce, label: "replacement", fees: g.Fees},
+		}
+	}
+	return []mempoolTx{{nonce: sender.GetNonce(), label: mempoolInOrder, fees: g.Fees}}
+}
+
+// patternSize returns the number of txs of a pattern.
+func (w *mempoolWorkload) patternSize(pattern string) (int, bool) {
+	switch pattern {
+	case mempoolInOrder:
+		return 1, true
+	case mempoolSwap, mempoolReplace:
+		return 2, true
+	case mempoolGap:
+		return w.gapSize + 1, true
+	}
+	return 0, false
+}
+
+func (w *mempoolWorkload) Labels() map[common.Hash]string {
+	return w.labels
+}
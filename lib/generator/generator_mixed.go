@@ -0,0 +1,188 @@
+package generator
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/0glabs/evmchainbench/lib/account"
+)
+
+func init() {
+	Register(func() Workload { return &mixedWorkload{} })
+}
+
+type mixedWorkload struct {
+	workloads   []Workload
+	weights     []int
+	totalWeight int
+
+	rngs []*rand.Rand
+	// seqs counts the txs of every sender per combined workload, which
+	// numbers them as if they were generated on their own
+	seqs [][]int
+
+	mutex  sync.Mutex
+	labels map[common.Hash]string
+	// the labels of the combined workloads are added once all txs exist
+	mergeOnce sync.Once
+}
+
+func (w *mixedWorkload) Name() string {
+	return "mixed"
+}
+
+func (w *mixedWorkload) Describe() string {
+	return "Interleaves other workloads per sender by weight, e.g. -w simple=50,erc20=30,uniswap=20. " +
+		"Options of a combined workload are prefixed with its name, e.g. counter.mode=per-sender. " +
+		"The mempool workload sets its own nonces and cannot be combined"
+}
+
+func (w *mixedWorkload) Validate(params Params) error {
+	subParams := make(map[string]Params)
+	weights := make(map[string]int)
+
+	for key, value := range params {
+		if name, opt, ok := strings.Cut(key, "."); ok {
+			if subParams[name] == nil {
+				subParams[name] = Params{}
+			}
+			subParams[name][opt] = value
+			continue
+		}
+
+		weight, err := strconv.Atoi(value)
+		if err != nil || weight <= 0 {
+			return fmt.Errorf("weight of %q must be a positive integer, got %q", key, value)
+		}
+		weights[key] = weight
+	}
+
+	if len(weights) == 0 {
+		return fmt.Errorf("no workload weights given, expected e.g. simple=50,erc20=30,uniswap=20")
+	}
+
+	for name := range subParams {
+		if _, ok := weights[name]; !ok {
+			return fmt.Errorf("options given for %q which has no weight", name)
+		}
+	}
+
+	names := make([]string, 0, len(weights))
+	for name := range weights {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if name == w.Name() {
+			return fmt.Errorf("%q cannot be nested", name)
+		}
+
+		sub, err := NewWorkload(name, subParams[name])
+		if err != nil {
+			return err
+		}
+		if _, ok := sub.(*mempoolWorkload); ok {
+			return fmt.Errorf("%q sets its own nonces and cannot be combined with other workloads", name)
+		}
+		// geth reserves the account of a pending blob tx for blob txs, the
+		// other txs of its sender would be rejected
+		if _, ok := sub.(*blobWorkload); ok {
+			return fmt.Errorf("%q txs keep their senders from sending other txs and cannot be combined with other workloads", name)
+		}
+
+		w.workloads = append(w.workloads, sub)
+		w.weights = append(w.weights, weights[name])
+		w.totalWeight += weights[name]
+	}
+
+	return nil
+}
+
+func (w *mixedWorkload) Prepare(g *Generator) error {
+	for _, sub := range w.workloads {
+		err := sub.Prepare(g)
+		if err != nil {
+			return fmt.Errorf("failed to prepare %q: %w", sub.Name(), err)
+		}
+	}
+
+	// every sender is generated by its own goroutine, so each gets its own source
+	w.rngs = make([]*rand.Rand, len(g.Senders))
+	w.seqs = make([][]int, len(g.Senders))
+	for i := range w.rngs {
+		w.rngs[i] = rand.New(rand.NewSource(int64(i)))
+		w.seqs[i] = make([]int, len(w.workloads))
+	}
+	w.labels = make(map[common.Hash]string)
+
+	return nil
+}
+
+func (w *mixedWorkload) GenerateTx(g *Generator, senderIndex int, sender *account.Account, seq int) (*types.Transaction, error) {
+	pick := w.rngs[senderIndex].Intn(w.totalWeight)
+
+	picked := len(w.workloads) - 1
+	for i, weight := range w.weights {
+		if pick < weight {
+			picked = i
+			break
+		}
+		pick -= weight
+	}
+
+	// e.g. weth alternates deposits and withdrawals by seq, so every workload
+	// numbers the txs of the sender it generates itself
+	sub := w.workloads[picked]
+	subSeq := w.seqs[senderIndex][picked]
+	w.seqs[senderIndex][picked]++
+
+	tx, err := sub.GenerateTx(g, senderIndex, sender, subSeq)
+	if err != nil {
+		return nil, err
+	}
+
+	w.mutex.Lock()
+	w.labels[tx.Hash()] = sub.Name()
+	w.mutex.Unlock()
+
+	return tx, nil
+}
+
+// Labels reports the txs of a labelling workload by workload and label, e.g.
+// "revert/out of gas".
+func (w *mixedWorkload) Labels() map[common.Hash]string {
+	w.mergeOnce.Do(func() {
+		for _, sub := range w.workloads {
+			labeler, ok := sub.(Labeler)
+			if !ok {
+				continue
+			}
+			for hash, label := range labeler.Labels() {
+				w.labels[hash] = sub.Name() + "/" + label
+			}
+		}
+	})
+	return w.labels
+}
+
+// ExpectFailure asks the workload which generated the txs of a label.
+func (w *mixedWorkload) ExpectFailure(label string) bool {
+	name, subLabel, ok := strings.Cut(label, "/")
+	if !ok {
+		return false
+	}
+	for _, sub := range w.workloads {
+		if expecter, ok := sub.(FailureExpecter); ok && sub.Name() == name {
+			return expecter.ExpectFailure(subLabel)
+		}
+	}
+	return false
+}
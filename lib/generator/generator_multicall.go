@@ -0,0 +1,182 @@
+package generator
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/0glabs/evmchainbench/lib/account"
+	"github.com/0glabs/evmchainbench/lib/contract_meta_data/counter"
+	"github.com/0glabs/evmchainbench/lib/contract_meta_data/erc20"
+	"github.com/0glabs/evmchainbench/lib/contract_meta_data/multicall"
+)
+
+func init() {
+	Register(func() Workload { return &multicallWorkload{} })
+}
+
+const (
+	multicallCallERC20   = "erc20"
+	multicallCallCounter = "counter"
+
+	multicallMaxBatch = 1000
+)
+
+// multicallCall is an entry of the calls of Multicall.aggregate.
+type multicallCall struct {
+	Target   common.Address
+	CallData []byte
+}
+
+// multicallWorkload bundles `batch` ERC20 transfers or counter increments
+// into every transaction through a multicall contract. With direct=true the
+// same calls are sent as one transaction each instead, so that "N txs" and
+// "1 tx with N calls" can be compared at equal gas.
+type multicallWorkload struct {
+	call   string
+	batch  int
+	direct bool
+
+	target      common.Address
+	multicall   common.Address
+	targetJSON  string
+	targetABI   abi.ABI
+	amount      *big.Int
+	estimateGas uint64
+}
+
+func (w *multicallWorkload) Name() string {
+	return "multicall"
+}
+
+func (w *multicallWorkload) Describe() string {
+	return "Transactions batching many calls through a multicall contract. Options: call=erc20|counter (default erc20), " +
+		"batch=calls per tx (default 10), direct=true to send every call as a tx of its own instead, " +
+		"ERC20 transfers then use transfer rather than an allowance and transferFrom (default false)"
+}
+
+func (w *multicallWorkload) Validate(params Params) error {
+	err := params.Check("call", "batch", "direct")
+	if err != nil {
+		return err
+	}
+
+	switch w.call = params.String("call", multicallCallERC20); w.call {
+	case multicallCallERC20, multicallCallCounter:
+	default:
+		return fmt.Errorf("unknown call %q, expected %s or %s", w.call, multicallCallERC20, multicallCallCounter)
+	}
+
+	w.batch, err = params.Int("batch", 10)
+	if err != nil {
+		return err
+	}
+	if w.batch < 1 || w.batch > multicallMaxBatch {
+		return fmt.Errorf("batch must be between 1 and %d", multicallMaxBatch)
+	}
+
+	w.direct, err = params.Bool("direct", false)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (w *multicallWorkload) Prepare(g *Generator) error {
+	var err error
+	w.targetJSON = counter.CounterABI
+	if w.call == multicallCallERC20 {
+		w.targetJSON = erc20.MyTokenABI
+		w.target, err = g.prepareContractERC20()
+	} else {
+		w.target, err = g.deployContract(counterContractGasLimit, counter.CounterBin, counter.CounterABI)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Multicall target %s contract: %s\n", w.call, w.target.Hex())
+	w.targetABI, err = abi.JSON(strings.NewReader(w.targetJSON))
+	if err != nil {
+		return err
+	}
+	// a small amount so that the tokens of a sender last for any batch
+	w.amount = big.NewInt(1)
+
+	if !w.direct {
+		w.multicall, err = g.deployContract(multicallContractGasLimit, multicall.MulticallBin, multicall.MulticallABI)
+		if err != nil {
+			return err
+		}
+		fmt.Println("Multicall contract:", w.multicall.Hex())
+	}
+
+	g.prepareSenders()
+
+	if w.call == multicallCallERC20 {
+		g.prepareERC20(w.target.Hex())
+		if !w.direct {
+			g.approveERC20(w.target, w.multicall)
+		}
+	}
+
+	// The first calls are the most expensive ones as they set fresh slots.
+	// The faucet is used as it is funded and approved already, unlike the
+	// fresh senders.
+	faucet := g.FaucetAccount
+	tx, err := w.tx(g, faucet, 0, 0)
+	if err != nil {
+		return err
+	}
+	msg := ConvertLegacyTxToCallMsg(tx, faucet.Address)
+	// batches may need more than any fixed limit, the node caps the
+	// estimation at the gas limit of the block
+	msg.Gas = 0
+	w.estimateGas = g.estimateGas(msg)
+
+	calls := w.batch
+	if w.direct {
+		calls = 1
+	}
+	fmt.Printf("Multicall: %d %s calls per tx, estimated gas: %d, %d per call\n", calls, w.call, w.estimateGas, w.estimateGas/uint64(calls))
+
+	return nil
+}
+
+func (w *multicallWorkload) GenerateTx(g *Generator, senderIndex int, sender *account.Account, seq int) (*types.Transaction, error) {
+	return w.tx(g, sender, sender.GetNonce(), seq)
+}
+
+func (w *multicallWorkload) tx(g *Generator, sender *account.Account, nonce uint64, seq int) (*types.Transaction, error) {
+	if w.direct {
+		method, args := "increment", []interface{}(nil)
+		if w.call == multicallCallERC20 {
+			method, args = "transfer", []interface{}{common.HexToAddress(g.Recipients[seq]), w.amount}
+		}
+		return GenerateContractCallingTx(sender.PrivateKey, w.target.Hex(), nonce, g.TxType(), g.ChainID, g.Fees,
+			w.estimateGas, w.targetJSON, method, args...), nil
+	}
+
+	calls := make([]multicallCall, w.batch)
+	for i := range calls {
+		var data []byte
+		var err error
+		if w.call == multicallCallERC20 {
+			// every tx pays a batch of recipients of its own
+			recipient := common.HexToAddress(g.Recipients[(seq*w.batch+i)%len(g.Recipients)])
+			data, err = w.targetABI.Pack("transferFrom", sender.Address, recipient, w.amount)
+		} else {
+			data, err = w.targetABI.Pack("increment")
+		}
+		if err != nil {
+			return nil, err
+		}
+		calls[i] = multicallCall{Target: w.target, CallData: data}
+	}
+	return GenerateContractCallingTx(sender.PrivateKey, w.multicall.Hex(), nonce, g.TxType(), g.ChainID, g.Fees,
+		w.estimateGas, multicall.MulticallABI, "aggregate", calls), nil
+}
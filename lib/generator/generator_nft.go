@@ -0,0 +1,208 @@
+package generator
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/0glabs/evmchainbench/lib/account"
+	"github.com/0glabs/evmchainbench/lib/contract_meta_data/nft"
+)
+
+func init() {
+	Register(func() Workload { return &nftWorkload{} })
+}
+
+const (
+	nftStandardERC721  = "erc721"
+	nftStandardERC1155 = "erc1155"
+
+	nftModeMint     = "mint"
+	nftModeTransfer = "transfer"
+	nftModeBatch    = "batch"
+
+	// ERC1155 transfers cycle through this many token ids
+	nftTokenIDs = 16
+	// ERC721 tokens minted to a sender by one preparation tx
+	nftMintBatchSize = 200
+)
+
+type nftWorkload struct {
+	standard  string
+	mode      string
+	batchSize int
+
+	contractAddress common.Address
+	contractABI     string
+	method          string
+	estimateGas     uint64
+}
+
+func (w *nftWorkload) Name() string {
+	return "nft"
+}
+
+func (w *nftWorkload) Describe() string {
+	return "NFT mints and transfers. Options: standard=erc721|erc1155 (default erc721), " +
+		"mode=mint|transfer|batch (default mint, batch is safeBatchTransferFrom and needs erc1155), batch-size=ids per batch (default 10)"
+}
+
+func (w *nftWorkload) Validate(params Params) error {
+	err := params.Check("standard", "mode", "batch-size")
+	if err != nil {
+		return err
+	}
+
+	w.standard = params.String("standard", nftStandardERC721)
+	switch w.standard {
+	case nftStandardERC721:
+		w.contractABI = nft.BenchERC721ABI
+	case nftStandardERC1155:
+		w.contractABI = nft.BenchERC1155ABI
+	default:
+		return fmt.Errorf("unknown standard %q, expected %s or %s", w.standard, nftStandardERC721, nftStandardERC1155)
+	}
+
+	w.mode = params.String("mode", nftModeMint)
+	switch w.mode {
+	case nftModeMint:
+		w.method = "mint"
+	case nftModeTransfer:
+		w.method = "transferFrom"
+		if w.standard == nftStandardERC1155 {
+			w.method = "safeTransferFrom"
+		}
+	case nftModeBatch:
+		if w.standard != nftStandardERC1155 {
+			return fmt.Errorf("mode %s needs standard=%s", nftModeBatch, nftStandardERC1155)
+		}
+		w.method = "safeBatchTransferFrom"
+	default:
+		return fmt.Errorf("unknown mode %q, expected %s, %s or %s", w.mode, nftModeMint, nftModeTransfer, nftModeBatch)
+	}
+
+	w.batchSize, err = params.Int("batch-size", 10)
+	if err != nil {
+		return err
+	}
+	if w.batchSize < 1 {
+		return fmt.Errorf("batch-size must be at least 1")
+	}
+
+	return nil
+}
+
+func (w *nftWorkload) Prepare(g *Generator) error {
+	var err error
+	if w.standard == nftStandardERC721 {
+		w.contractAddress, err = g.deployContract(nftContractGasLimit, nft.BenchERC721Bin, nft.BenchERC721ABI)
+	} else {
+		w.contractAddress, err = g.deployContract(nftContractGasLimit, nft.BenchERC1155Bin, nft.BenchERC1155ABI)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Println("NFT contract:", w.standard, w.contractAddress.Hex())
+
+	g.prepareSenders()
+
+	if w.mode != nftModeMint {
+		w.premint(g)
+	}
+
+	// Mints are estimated from the faucet with a token no sender uses,
+	// transfers from the first sender once it holds its tokens.
+	sender := g.Senders[0]
+	nonce := uint64(0)
+	args := w.args(g, 0, sender, 0)
+	if w.mode == nftModeMint {
+		sender = g.FaucetAccount
+		if w.standard == nftStandardERC721 {
+			args[1] = big.NewInt(int64(len(g.Senders) * len(g.Recipients)))
+		}
+	}
+	tx := GenerateContractCallingTx(sender.PrivateKey, w.contractAddress.Hex(), nonce, g.TxType(), g.ChainID, g.Fees,
+		nftCallGasLimit, w.contractABI, w.method, args...)
+	w.estimateGas = g.estimateGas(ConvertLegacyTxToCallMsg(tx, sender.Address))
+
+	fmt.Println("Estimated gas:", w.estimateGas)
+
+	return nil
+}
+
+// premint hands every sender the tokens it transfers during the run.
+func (w *nftWorkload) premint(g *Generator) {
+	txCount := len(g.Recipients)
+	argsList := [][]interface{}{}
+
+	if w.standard == nftStandardERC721 {
+		for index, sender := range g.Senders {
+			for start := 0; start < txCount; start += nftMintBatchSize {
+				count := min(nftMintBatchSize, txCount-start)
+				argsList = append(argsList, []interface{}{sender.Address, w.erc721TokenID(g, index, start), big.NewInt(int64(count))})
+			}
+		}
+		g.prepareContractCalls(nftMintBatchGasLimit, w.contractAddress, w.contractABI, "mintBatch", argsList, 10*time.Minute)
+		return
+	}
+
+	amount := big.NewInt(int64(txCount * w.batchSize))
+	for _, sender := range g.Senders {
+		for id := 0; id < nftTokenIDs; id++ {
+			argsList = append(argsList, []interface{}{sender.Address, big.NewInt(int64(id)), amount})
+		}
+	}
+	g.prepareContractCalls(nftCallGasLimit, w.contractAddress, w.contractABI, "mint", argsList, 10*time.Minute)
+}
+
+func (w *nftWorkload) erc721TokenID(g *Generator, senderIndex, seq int) *big.Int {
+	return big.NewInt(int64(senderIndex*len(g.Recipients) + seq))
+}
+
+func (w *nftWorkload) args(g *Generator, senderIndex int, sender *account.Account, seq int) []interface{} {
+	recipient := common.HexToAddress(g.Recipients[seq])
+	one := big.NewInt(1)
+
+	if w.standard == nftStandardERC721 {
+		id := w.erc721TokenID(g, senderIndex, seq)
+		if w.mode == nftModeMint {
+			return []interface{}{recipient, id}
+		}
+		return []interface{}{sender.Address, recipient, id}
+	}
+
+	id := big.NewInt(int64(seq % nftTokenIDs))
+	switch w.mode {
+	case nftModeMint:
+		return []interface{}{recipient, id, one}
+	case nftModeTransfer:
+		return []interface{}{sender.Address, recipient, id, one, []byte{}}
+	}
+
+	ids := make([]*big.Int, w.batchSize)
+	amounts := make([]*big.Int, w.batchSize)
+	for i := range ids {
+		ids[i] = big.NewInt(int64((seq*w.batchSize + i) % nftTokenIDs))
+		amounts[i] = one
+	}
+	return []interface{}{sender.Address, recipient, ids, amounts, []byte{}}
+}
+
+func (w *nftWorkload) GenerateTx(g *Generator, senderIndex int, sender *account.Account, seq int) (*types.Transaction, error) {
+	tx := GenerateContractCallingTx(
+		sender.PrivateKey,
+		w.contractAddress.Hex(),
+		sender.GetNonce(),
+		g.TxType(),
+		g.ChainID,
+		g.Fees,
+		w.estimateGas,
+		w.contractABI,
+		w.method,
+		w.args(g, senderIndex, sender, seq)...,
+	)
+	return tx, nil
+}
@@ -0,0 +1,272 @@
+package generator
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/bn256"
+
+	"github.com/0glabs/evmchainbench/lib/account"
+	"github.com/0glabs/evmchainbench/lib/contract_meta_data/precompilebench"
+)
+
+func init() {
+	Register(func() Workload { return &precompileWorkload{} })
+}
+
+// precompileAddresses are the standard precompiles up to Istanbul
+var precompileAddresses = map[string]common.Address{
+	"ecrecover":    common.BytesToAddress([]byte{1}),
+	"sha256":       common.BytesToAddress([]byte{2}),
+	"ripemd160":    common.BytesToAddress([]byte{3}),
+	"identity":     common.BytesToAddress([]byte{4}),
+	"modexp":       common.BytesToAddress([]byte{5}),
+	"bn256add":     common.BytesToAddress([]byte{6}),
+	"bn256mul":     common.BytesToAddress([]byte{7}),
+	"bn256pairing": common.BytesToAddress([]byte{8}),
+	"blake2f":      common.BytesToAddress([]byte{9}),
+}
+
+type precompileWorkload struct {
+	names       []string
+	weights     []int
+	totalWeight int
+	calls       int
+
+	contractAddress common.Address
+	inputs          map[string][]byte
+	estimateGas     map[string]uint64
+
+	rngs []*rand.Rand
+
+	mutex  sync.Mutex
+	labels map[common.Hash]string
+}
+
+func (w *precompileWorkload) Name() string {
+	return "precompile"
+}
+
+func (w *precompileWorkload) Describe() string {
+	return "Precompile calls through a dispatcher contract, mixed by weight, e.g. -w ecrecover=2,modexp=1 (default all with equal weight). " +
+		"Precompiles: " + strings.Join(precompileNames(), ", ") + ". Options: calls=precompile calls per tx (default 1)"
+}
+
+func (w *precompileWorkload) Validate(params Params) error {
+	var err error
+	w.calls, err = params.Int("calls", 1)
+	if err != nil {
+		return err
+	}
+	if w.calls < 1 {
+		return fmt.Errorf("calls must be at least 1")
+	}
+
+	weights := make(map[string]int)
+	for key, value := range params {
+		if key == "calls" {
+			continue
+		}
+		if _, ok := precompileAddresses[key]; !ok {
+			return fmt.Errorf("unknown precompile %q, expected one of %s", key, strings.Join(precompileNames(), ", "))
+		}
+		weight, err := strconv.Atoi(value)
+		if err != nil || weight <= 0 {
+			return fmt.Errorf("weight of %q must be a positive integer, got %q", key, value)
+		}
+		weights[key] = weight
+	}
+	if len(weights) == 0 {
+		for _, name := range precompileNames() {
+			weights[name] = 1
+		}
+	}
+
+	for _, name := range precompileNames() {
+		if weight, ok := weights[name]; ok {
+			w.names = append(w.names, name)
+			w.weights = append(w.weights, weight)
+			w.totalWeight += weight
+		}
+	}
+
+	return nil
+}
+
+func (w *precompileWorkload) Prepare(g *Generator) error {
+	var err error
+	w.contractAddress, err = g.deployContract(precompileBenchContractGasLimit, precompilebench.PrecompileBenchBin, precompilebench.PrecompileBenchABI)
+	if err != nil {
+		return err
+	}
+	fmt.Println("PrecompileBench contract:", w.contractAddress.Hex())
+
+	g.prepareSenders()
+
+	w.inputs = make(map[string][]byte)
+	w.estimateGas = make(map[string]uint64)
+	faucet := g.FaucetAccount
+	for _, name := range w.names {
+		w.inputs[name], err = precompileInput(name, g)
+		if err != nil {
+			return err
+		}
+
+		// the faucet is funded already, unlike the fresh senders
+		tx := GenerateContractCallingTx(faucet.PrivateKey, w.contractAddress.Hex(), 0, g.TxType(), g.ChainID, g.Fees,
+			precompileCallGasLimit*uint64(w.calls), precompilebench.PrecompileBenchABI, "run", w.args(name)...)
+		w.estimateGas[name] = g.estimateGas(ConvertLegacyTxToCallMsg(tx, faucet.Address))
+		fmt.Printf("Precompile %s: %d bytes input, estimated gas: %d\n", name, len(w.inputs[name]), w.estimateGas[name])
+	}
+
+	w.rngs = make([]*rand.Rand, len(g.Senders))
+	for i := range w.rngs {
+		w.rngs[i] = rand.New(rand.NewSource(int64(i)))
+	}
+	w.labels = make(map[common.Hash]string)
+
+	return nil
+}
+
+func (w *precompileWorkload) args(name string) []interface{} {
+	return []interface{}{precompileAddresses[name], big.NewInt(int64(w.calls)), w.inputs[name]}
+}
+
+func (w *precompileWorkload) GenerateTx(g *Generator, senderIndex int, sender *account.Account, seq int) (*types.Transaction, error) {
+	pick := w.rngs[senderIndex].Intn(w.totalWeight)
+
+	name := w.names[len(w.names)-1]
+	for i, weight := range w.weights {
+		if pick < weight {
+			name = w.names[i]
+			break
+		}
+		pick -= weight
+	}
+
+	tx := GenerateContractCallingTx(
+		sender.PrivateKey,
+		w.contractAddress.Hex(),
+		sender.GetNonce(),
+		g.TxType(),
+		g.ChainID,
+		g.Fees,
+		w.estimateGas[name],
+		precompilebench.PrecompileBenchABI,
+		"run",
+		w.args(name)...,
+	)
+
+	if len(w.names) > 1 {
+		w.mutex.Lock()
+		w.labels[tx.Hash()] = name
+		w.mutex.Unlock()
+	}
+
+	return tx, nil
+}
+
+func (w *precompileWorkload) Labels() map[common.Hash]string {
+	return w.labels
+}
+
+func precompileNames() []string {
+	names := make([]string, 0, len(precompileAddresses))
+	for name := range precompileAddresses {
+		names = append(names, name)
+	}
+	// in address order, which is also the order of introduction
+	sort.Slice(names, func(i, j int) bool {
+		return precompileAddresses[names[i]].Big().Cmp(precompileAddresses[names[j]].Big()) < 0
+	})
+	return names
+}
+
+// precompileInput returns a valid input of the kind found on chain, e.g. a
+// signature for ecrecover and an RSA-2048 verification for modexp.
+func precompileInput(name string, g *Generator) ([]byte, error) {
+	k1, k2 := big.NewInt(0x10c4), big.NewInt(0x2a17)
+
+	switch name {
+	case "ecrecover":
+		hash := crypto.Keccak256([]byte("evmchainbench"))
+		sig, err := crypto.Sign(hash, g.FaucetAccount.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		input := append([]byte{}, hash...)
+		input = append(input, common.LeftPadBytes([]byte{sig[64] + 27}, 32)...)
+		return append(input, sig[:64]...), nil
+	case "sha256", "ripemd160", "identity":
+		return precompileBytes(256), nil
+	case "modexp":
+		base, exponent, modulus := precompileBytes(256), big.NewInt(65537).Bytes(), precompileBytes(256)
+		base[0] &= 0x7f
+		modulus[0] |= 0x80
+		modulus[len(modulus)-1] |= 1
+		input := common.LeftPadBytes(big.NewInt(int64(len(base))).Bytes(), 32)
+		input = append(input, common.LeftPadBytes(big.NewInt(int64(len(exponent))).Bytes(), 32)...)
+		input = append(input, common.LeftPadBytes(big.NewInt(int64(len(modulus))).Bytes(), 32)...)
+		input = append(input, base...)
+		input = append(input, exponent...)
+		return append(input, modulus...), nil
+	case "bn256add":
+		input := new(bn256.G1).ScalarBaseMult(k1).Marshal()
+		return append(input, new(bn256.G1).ScalarBaseMult(k2).Marshal()...), nil
+	case "bn256mul":
+		input := new(bn256.G1).ScalarBaseMult(k1).Marshal()
+		return append(input, common.LeftPadBytes(k2.Bytes(), 32)...), nil
+	case "bn256pairing":
+		// e(P, Q) * e(-P, Q) == 1, the shape of a two pairing proof check
+		p := new(bn256.G1).ScalarBaseMult(k1)
+		q := new(bn256.G2).ScalarBaseMult(k2)
+		input := append(p.Marshal(), q.Marshal()...)
+		input = append(input, new(bn256.G1).Neg(p).Marshal()...)
+		return append(input, q.Marshal()...), nil
+	case "blake2f":
+		return blake2fInput(), nil
+	}
+
+	return nil, fmt.Errorf("no input for precompile %q", name)
+}
+
+func precompileBytes(n int) []byte {
+	data := make([]byte, 0, n+32)
+	seed := []byte("evmchainbench")
+	for len(data) < n {
+		seed = crypto.Keccak256(seed)
+		data = append(data, seed...)
+	}
+	return data[:n]
+}
+
+// blake2fInput is the compression of the single block "abc" by BLAKE2b-512
+// with the 12 rounds of the full hash, as in EIP-152.
+func blake2fInput() []byte {
+	iv := []uint64{
+		0x6a09e667f3bcc908, 0xbb67ae8584caa73b, 0x3c6ef372fe94f82b, 0xa54ff53a5f1d36f1,
+		0x510e527fade682d1, 0x9b05688c2b3e6c1f, 0x1f83d9abfb41bd6b, 0x5be0cd19137e2179,
+	}
+	// parameter block: 64 byte digest, no key, fanout and depth 1
+	iv[0] ^= 0x01010040
+
+	input := binary.BigEndian.AppendUint32(nil, 12)
+	for _, h := range iv {
+		input = binary.LittleEndian.AppendUint64(input, h)
+	}
+	message := make([]byte, 128)
+	copy(message, "abc")
+	input = append(input, message...)
+	input = binary.LittleEndian.AppendUint64(input, 3)
+	input = binary.LittleEndian.AppendUint64(input, 0)
+	return append(input, 1)
+}
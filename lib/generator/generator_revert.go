@@ -0,0 +1,199 @@
+package generator
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/0glabs/evmchainbench/lib/account"
+	"github.com/0glabs/evmchainbench/lib/contract_meta_data/failbench"
+)
+
+func init() {
+	Register(func() Workload { return &revertWorkload{} })
+}
+
+const (
+	revertModeRequire = "require"
+	revertModeOOG     = "oog"
+	revertModeDeep    = "deep"
+	revertModeAll     = "all"
+
+	revertLabelSuccess = "success"
+)
+
+var revertModes = []string{revertModeRequire, revertModeOOG, revertModeDeep}
+
+// revertLabels names the failing txs of every mode in the summary
+var revertLabels = map[string]string{
+	revertModeRequire: "require(false)",
+	revertModeOOG:     "out of gas",
+	revertModeDeep:    "deep revert",
+}
+
+// revertWorkload sends calls which are included but fail, mixed with calls
+// which succeed, so that the cost of failed execution shows in the block
+// space used. The listener checks that every receipt has the expected status.
+type revertWorkload struct {
+	share  float64
+	modes  []string
+	depth  int
+	oogGas uint64
+
+	contractAddress common.Address
+	succeedGas      uint64
+	nestGas         uint64
+	senders         []*revertSender
+
+	mutex  sync.Mutex
+	labels map[common.Hash]string
+}
+
+// revertSender spreads the failing calls evenly over the txs of a sender.
+type revertSender struct {
+	due    float64
+	failed int
+}
+
+func (w *revertWorkload) Name() string {
+	return "revert"
+}
+
+func (w *revertWorkload) Describe() string {
+	return "Contract calls a share of which fail on chain, checked against their receipts. Options: share=fraction of failing calls (default 0.5), " +
+		"mode=require|oog|deep|all (default all, round robin over the others): require=a counter bump rolled back by require(false), " +
+		"oog=counter bumps until the gas runs out, deep=a revert at the bottom of a stack of depth self calls, " +
+		"depth=call depth of mode=deep (default 8), oog-gas=gas limit of mode=oog, all of it burnt (default 100000)"
+}
+
+func (w *revertWorkload) Validate(params Params) error {
+	err := params.Check("share", "mode", "depth", "oog-gas")
+	if err != nil {
+		return err
+	}
+
+	w.share, err = params.Float("share", 0.5)
+	if err != nil {
+		return err
+	}
+	if w.share < 0 || w.share > 1 {
+		return fmt.Errorf("share must be between 0 and 1")
+	}
+
+	switch mode := params.String("mode", revertModeAll); mode {
+	case revertModeRequire, revertModeOOG, revertModeDeep:
+		w.modes = []string{mode}
+	case revertModeAll:
+		w.modes = revertModes
+	default:
+		return fmt.Errorf("unknown mode %q, expected %s, %s, %s or %s", mode, revertModeRequire, revertModeOOG, revertModeDeep, revertModeAll)
+	}
+
+	w.depth, err = params.Int("depth", 8)
+	if err != nil {
+		return err
+	}
+	// every frame only forwards 63/64 of its gas
+	if w.depth < 1 || w.depth > 256 {
+		return fmt.Errorf("depth must be between 1 and 256")
+	}
+
+	oogGas, err := params.Int("oog-gas", 100000)
+	if err != nil {
+		return err
+	}
+	if oogGas < 30000 {
+		return fmt.Errorf("oog-gas must be at least 30000")
+	}
+	w.oogGas = uint64(oogGas)
+
+	return nil
+}
+
+func (w *revertWorkload) Prepare(g *Generator) error {
+	var err error
+	w.contractAddress, err = g.deployContract(failBenchContractGasLimit, failbench.FailBenchBin, failbench.FailBenchABI)
+	if err != nil {
+		return err
+	}
+	fmt.Println("FailBench contract:", w.contractAddress.Hex())
+
+	g.prepareSenders()
+
+	// failing calls cannot be estimated, they get the gas of their
+	// successful twins
+	w.succeedGas = w.estimate(g, "succeed")
+	w.nestGas = w.estimate(g, "nest", big.NewInt(int64(w.depth)), false)
+	fmt.Printf("Revert: share=%.2f modes %v, gas of require(false): %d, out of gas: %d, deep revert: %d\n",
+		w.share, w.modes, w.succeedGas, w.oogGas, w.nestGas)
+
+	w.senders = make([]*revertSender, len(g.Senders))
+	for i := range w.senders {
+		w.senders[i] = &revertSender{}
+	}
+	w.labels = make(map[common.Hash]string)
+
+	return nil
+}
+
+// estimate uses the faucet, it is funded already, unlike the fresh senders
+func (w *revertWorkload) estimate(g *Generator, method string, args ...interface{}) uint64 {
+	faucet := g.FaucetAccount
+	tx := GenerateContractCallingTx(faucet.PrivateKey, w.contractAddress.Hex(), 0, g.TxType(), g.ChainID, g.Fees,
+		failBenchCallGasLimit, failbench.FailBenchABI, method, args...)
+	return g.estimateGas(ConvertLegacyTxToCallMsg(tx, faucet.Address))
+}
+
+func (w *revertWorkload) GenerateTx(g *Generator, senderIndex int, sender *account.Account, seq int) (*types.Transaction, error) {
+	s := w.senders[senderIndex]
+	label := revertLabelSuccess
+	if s.due += w.share; s.due >= 1 {
+		s.due--
+		// senders start at different modes so that every block gets a mix
+		label = w.modes[(senderIndex+s.failed)%len(w.modes)]
+		s.failed++
+	}
+
+	method, gasLimit, args := "succeed", w.succeedGas, []interface{}(nil)
+	switch label {
+	case revertModeRequire:
+		method = "fail"
+	case revertModeOOG:
+		method, gasLimit = "burn", w.oogGas
+	case revertModeDeep:
+		method, gasLimit, args = "nest", w.nestGas, []interface{}{big.NewInt(int64(w.depth)), true}
+	}
+
+	tx := GenerateContractCallingTx(
+		sender.PrivateKey,
+		w.contractAddress.Hex(),
+		sender.GetNonce(),
+		g.TxType(),
+		g.ChainID,
+		g.Fees,
+		gasLimit,
+		failbench.FailBenchABI,
+		method,
+		args...,
+	)
+
+	if name, ok := revertLabels[label]; ok {
+		label = name
+	}
+	w.mutex.Lock()
+	w.labels[tx.Hash()] = label
+	w.mutex.Unlock()
+
+	return tx, nil
+}
+
+func (w *revertWorkload) Labels() map[common.Hash]string {
+	return w.labels
+}
+
+func (w *revertWorkload) ExpectFailure(label string) bool {
+	return label != revertLabelSuccess
+}
@@ -1,61 +1,39 @@
 package generator
 
 import (
-	"log"
 	"math/big"
-	"sync"
 
 	"github.com/0glabs/evmchainbench/lib/account"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
-func (g *Generator) GenerateSimple() (map[int]types.Transactions, error) {
-	txsMap := make(map[int]types.Transactions)
+func init() {
+	Register(func() Workload { return &simpleWorkload{} })
+}
+
+type simpleWorkload struct {
+	value *big.Int
+}
+
+func (w *simpleWorkload) Name() string {
+	return "simple"
+}
 
-	if g.ShouldPersist {
-		defer g.Store.PersistPrepareTxs()
-	}
+func (w *simpleWorkload) Describe() string {
+	return "Native token transfers from every sender to random recipients"
+}
+
+func (w *simpleWorkload) Validate(params Params) error {
+	return params.Check()
+}
 
+func (w *simpleWorkload) Prepare(g *Generator) error {
 	g.prepareSenders()
 
-	value := big.NewInt(10000000000000) // 1/100,000 ETH
-
-	var mutex sync.Mutex
-	ch := make(chan error)
-	
-	log.Default().Println("Generating simple transfers...")
-	for index, sender := range g.Senders {
-		go func(index int, sender *account.Account) {
-			txs := types.Transactions{}
-			for _, recipient := range g.Recipients {
-				tx, err := GenerateSimpleTransferTx(sender.PrivateKey, recipient, sender.GetNonce(), g.ChainID, g.GasPrice, value, g.EIP1559)
-				if err != nil {
-					ch <- err
-					return
-				}
-				txs = append(txs, tx)
-			}
-
-			mutex.Lock()
-			txsMap[index] = txs
-			mutex.Unlock()
-			ch <- nil
-		}(index, sender)
-	}
-
-	for i := 0; i < len(g.Senders); i++ {
-		msg := <-ch
-		if msg != nil {
-			return txsMap, msg
-		}
-	}
-
-	if g.ShouldPersist {
-		err := g.Store.PersistTxsMap(txsMap)
-		if err != nil {
-			return txsMap, err
-		}
-	}
-
-	return txsMap, nil
+	w.value = big.NewInt(10000000000000) // 1/100,000 ETH
+	return nil
+}
+
+func (w *simpleWorkload) GenerateTx(g *Generator, senderIndex int, sender *account.Account, seq int) (*types.Transaction, error) {
+	return GenerateSimpleTransferTx(sender.PrivateKey, g.Recipients[seq], sender.GetNonce(), g.TxType(), g.ChainID, g.Fees, w.value)
 }
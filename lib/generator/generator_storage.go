@@ -0,0 +1,186 @@
+package generator
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/0glabs/evmchainbench/lib/account"
+	"github.com/0glabs/evmchainbench/lib/contract_meta_data/statebench"
+)
+
+func init() {
+	Register(func() Workload { return &storageWorkload{} })
+}
+
+const (
+	storageOpWrite = "write"
+	storageOpRead  = "read"
+	storageOpClear = "clear"
+
+	storageTargetFresh    = "fresh"
+	storageTargetExisting = "existing"
+
+	// existing slots are a window of this many regions per sender, cycled through
+	storageWindowRegions = 16
+	// slots written by one preparation tx
+	storagePrepareSlots = 200
+	// slots written at most during preparation, 5000 txs of the faucet
+	storageMaxPrepareSlots = 1000000
+)
+
+// storageWorkload makes every tx touch one region of consecutive slots. The
+// first len(Senders)*storageWindowRegions regions form the per sender windows
+// of existing slots, the regions after them are used once each.
+type storageWorkload struct {
+	op     string
+	target string
+	slots  int
+
+	contractAddress common.Address
+	estimateGas     uint64
+}
+
+func (w *storageWorkload) Name() string {
+	return "storage"
+}
+
+func (w *storageWorkload) Describe() string {
+	return "Storage heavy calls. Options: op=write|read|clear (default write), slots=slots per tx (default 10), " +
+		"target=fresh|existing (default fresh, clear always clears slots written during preparation for refunds)"
+}
+
+func (w *storageWorkload) Validate(params Params) error {
+	err := params.Check("op", "slots", "target")
+	if err != nil {
+		return err
+	}
+
+	w.op = params.String("op", storageOpWrite)
+	switch w.op {
+	case storageOpWrite, storageOpRead, storageOpClear:
+	default:
+		return fmt.Errorf("unknown op %q, expected %s, %s or %s", w.op, storageOpWrite, storageOpRead, storageOpClear)
+	}
+
+	w.target = params.String("target", storageTargetFresh)
+	switch w.target {
+	case storageTargetFresh, storageTargetExisting:
+	default:
+		return fmt.Errorf("unknown target %q, expected %s or %s", w.target, storageTargetFresh, storageTargetExisting)
+	}
+
+	w.slots, err = params.Int("slots", 10)
+	if err != nil {
+		return err
+	}
+	if w.slots < 1 {
+		return fmt.Errorf("slots must be at least 1")
+	}
+
+	return nil
+}
+
+func (w *storageWorkload) Prepare(g *Generator) error {
+	fmt.Printf("Storage: op=%s target=%s slots=%d per tx\n", w.op, w.target, w.slots)
+
+	prepareStart, prepareCount := 0, 0
+	switch {
+	case w.op == storageOpClear:
+		// every tx clears its own slots, so all of them are written upfront
+		prepareStart = w.regionStart(len(g.Senders) * storageWindowRegions)
+		prepareCount = len(g.Senders) * len(g.Recipients) * w.slots
+	case w.target == storageTargetExisting:
+		prepareCount = len(g.Senders) * storageWindowRegions * w.slots
+	}
+	if prepareCount > storageMaxPrepareSlots {
+		return fmt.Errorf("op=%s target=%s writes %d slots upfront, more than %d, lower slots, the sender count or the tx count",
+			w.op, w.target, prepareCount, storageMaxPrepareSlots)
+	}
+
+	var err error
+	w.contractAddress, err = g.deployContract(stateBenchContractGasLimit, statebench.StateBenchBin, statebench.StateBenchABI)
+	if err != nil {
+		return err
+	}
+	fmt.Println("StateBench contract:", w.contractAddress.Hex())
+
+	g.prepareSenders()
+
+	if prepareCount > 0 {
+		w.prepareSlots(g, prepareStart, prepareCount)
+	}
+
+	tx := GenerateContractCallingTx(g.FaucetAccount.PrivateKey, w.contractAddress.Hex(), 0, g.TxType(), g.ChainID, g.Fees,
+		stateBenchCallGasLimit, statebench.StateBenchABI, w.op, w.args(g, 0, 0)...)
+	w.estimateGas = g.estimateGas(ConvertLegacyTxToCallMsg(tx, g.FaucetAccount.Address))
+
+	fmt.Println("Estimated gas:", w.estimateGas)
+
+	return nil
+}
+
+func (w *storageWorkload) prepareSlots(g *Generator, start, count int) {
+	argsList := [][]interface{}{}
+	for offset := 0; offset < count; offset += storagePrepareSlots {
+		n := min(storagePrepareSlots, count-offset)
+		argsList = append(argsList, []interface{}{big.NewInt(int64(start + offset)), big.NewInt(int64(n)), big.NewInt(1)})
+	}
+	// a second per tx leaves room for blocks holding only a few of them
+	timeout := max(10*time.Minute, time.Duration(len(argsList))*time.Second)
+	log.Default().Println("Writing", count, "storage slots in", len(argsList), "txs, waiting up to", timeout, "...")
+	g.prepareContractCalls(stateBenchPrepareGasLimit, w.contractAddress, statebench.StateBenchABI, storageOpWrite, argsList, timeout)
+}
+
+func (w *storageWorkload) regionStart(region int) int {
+	return region * w.slots
+}
+
+func (w *storageWorkload) args(g *Generator, senderIndex, seq int) []interface{} {
+	var region int
+	if w.op != storageOpClear && w.target == storageTargetExisting {
+		region = senderIndex*storageWindowRegions + seq%storageWindowRegions
+	} else {
+		region = len(g.Senders)*storageWindowRegions + senderIndex*len(g.Recipients) + seq
+	}
+
+	start := big.NewInt(int64(w.regionStart(region)))
+	count := big.NewInt(int64(w.slots))
+
+	switch w.op {
+	case storageOpWrite:
+		// existing slots hold 1 or an earlier seq+2, so the value always changes
+		return []interface{}{start, count, big.NewInt(int64(seq + 2))}
+	default:
+		return []interface{}{start, count}
+	}
+}
+
+func (w *storageWorkload) GenerateTx(g *Generator, senderIndex int, sender *account.Account, seq int) (*types.Transaction, error) {
+	tx := GenerateContractCallingTx(
+		sender.PrivateKey,
+		w.contractAddress.Hex(),
+		sender.GetNonce(),
+		g.TxType(),
+		g.ChainID,
+		g.Fees,
+		w.estimateGas,
+		statebench.StateBenchABI,
+		w.op,
+		w.args(g, senderIndex, seq)...,
+	)
+	return tx, nil
+}
+
+func (w *storageWorkload) AccessList(tx *types.Transaction, from common.Address) types.AccessList {
+	start := callArg(tx, 0).Big().Int64()
+	slots := make([]common.Hash, w.slots)
+	for i := range slots {
+		slots[i] = common.BigToHash(big.NewInt(start + int64(i)))
+	}
+	return types.AccessList{{Address: *tx.To(), StorageKeys: slots}}
+}
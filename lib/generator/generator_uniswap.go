@@ -3,9 +3,9 @@ package generator
 import (
 	"encoding/json"
 	"fmt"
-	"io"
 	"log"
 	"math/big"
+	"math/rand"
 	"os"
 	"sync"
 	"time"
@@ -19,28 +19,137 @@ import (
 	"github.com/0glabs/evmchainbench/lib/contract_meta_data/uniswap"
 )
 
-func (g *Generator) GenerateUniswap() (map[int]types.Transactions, error) {
-	txsMap := make(map[int]types.Transactions)
+func init() {
+	Register(func() Workload { return &uniswapWorkload{} })
+}
 
-	if g.ShouldPersist {
-		defer g.Store.PersistPrepareTxs()
-	}
+const (
+	uniswapAssignDisjoint = "disjoint"
+	uniswapAssignShared   = "shared"
+	uniswapAssignRandom   = "random"
+)
+
+// uniswapWorkload swaps along routes of one or more hops. Pair i links token i
+// with token i+1; when there are as many pairs as tokens the last pair closes
+// the ring, otherwise the pairs form a chain. A route of h hops starting at
+// pair p goes through pairs p, p+1, ..., p+h-1. Disjoint routes start every h
+// pairs, so that no two of them share a pair.
+type uniswapWorkload struct {
+	tokenCount int
+	pairCount  int
+	hops       int
+	assign     string
+
+	router      common.Address
+	routes      [][]common.Address
+	deadline    *big.Int
+	estimateGas uint64
+
+	rngs []*rand.Rand
+
+	mutex  sync.Mutex
+	labels map[common.Hash]string
+}
+
+func (w *uniswapWorkload) Name() string {
+	return "uniswap"
+}
+
+func (w *uniswapWorkload) Describe() string {
+	return "Uniswap V2 swaps through the router. Options: tokens=N (default 2), pairs=M (default 1), " +
+		"hops=path length in pairs (default 1), assign=disjoint|shared|random senders to routes (default disjoint)"
+}
 
-	tokenA, err := g.deployContract(erc20ContractGasLimit, erc20.MyTokenBin, erc20.MyTokenABI, "Token A", "TOKENA")
+func (w *uniswapWorkload) Validate(params Params) error {
+	err := params.Check("tokens", "pairs", "hops", "assign")
 	if err != nil {
-		return txsMap, err
+		return err
 	}
 
-	tokenB, err := g.deployContract(erc20ContractGasLimit, erc20.MyTokenBin, erc20.MyTokenABI, "Token B", "TOKENB")
+	w.tokenCount, err = params.Int("tokens", 2)
 	if err != nil {
-		return txsMap, err
+		return err
+	}
+	w.pairCount, err = params.Int("pairs", w.tokenCount-1)
+	if err != nil {
+		return err
+	}
+	w.hops, err = params.Int("hops", 1)
+	if err != nil {
+		return err
+	}
+
+	if w.tokenCount < 2 {
+		return fmt.Errorf("tokens must be at least 2")
+	}
+	// two tokens can only form one pair, more tokens can be closed into a ring
+	maxPairs := w.tokenCount
+	if w.tokenCount == 2 {
+		maxPairs = 1
+	}
+	if w.pairCount < 1 || w.pairCount > maxPairs {
+		return fmt.Errorf("pairs must be between 1 and %d for %d tokens", maxPairs, w.tokenCount)
+	}
+	if w.hops < 1 {
+		return fmt.Errorf("hops must be at least 1")
 	}
+	if w.routeCount() < 1 {
+		return fmt.Errorf("a route of %d hops needs at least %d chained pairs", w.hops, w.hops)
+	}
+
+	w.assign = params.String("assign", uniswapAssignDisjoint)
+	switch w.assign {
+	case uniswapAssignDisjoint, uniswapAssignShared, uniswapAssignRandom:
+	default:
+		return fmt.Errorf("unknown assign %q, expected %s, %s or %s", w.assign, uniswapAssignDisjoint, uniswapAssignShared, uniswapAssignRandom)
+	}
+
+	return nil
+}
+
+func (w *uniswapWorkload) ring() bool {
+	return w.tokenCount > 2 && w.pairCount == w.tokenCount
+}
+
+func (w *uniswapWorkload) routeCount() int {
+	if w.ring() {
+		if w.hops >= w.tokenCount {
+			return 0
+		}
+		return w.pairCount
+	}
+	return w.pairCount - w.hops + 1
+}
 
-	fmt.Println("Token A:", tokenA.Hex(), "Token B:", tokenB.Hex())
+// routeStarts returns the first pair of every route.
+func (w *uniswapWorkload) routeStarts() []int {
+	count, step := w.routeCount(), 1
+	if w.assign == uniswapAssignDisjoint {
+		count, step = w.pairCount/w.hops, w.hops
+	}
+
+	starts := make([]int, count)
+	for r := range starts {
+		starts[r] = r * step
+	}
+	return starts
+}
+
+func (w *uniswapWorkload) Prepare(g *Generator) error {
+	tokens := make([]common.Address, w.tokenCount)
+	for i := range tokens {
+		token, err := g.deployContract(erc20ContractGasLimit, erc20.MyTokenBin, erc20.MyTokenABI, fmt.Sprintf("Token %d", i), fmt.Sprintf("TOKEN%d", i))
+		if err != nil {
+			return err
+		}
+		tokens[i] = token
+		fmt.Printf("Token %d: %s\n", i, token.Hex())
+	}
 
 	g.prepareSenders()
-	g.prepareERC20(tokenA.Hex())
-	g.prepareERC20(tokenB.Hex())
+	for _, token := range tokens {
+		g.prepareERC20(token.Hex())
+	}
 
 	var data []interface{}
 
@@ -48,107 +157,119 @@ func (g *Generator) GenerateUniswap() (map[int]types.Transactions, error) {
 	fmt.Println("Factory contract:", factory.Hex())
 	fmt.Println("Router contract:", router.Hex())
 
-	g.approveERC20(tokenA, router)
-	g.approveERC20(tokenB, router)
+	for i, token := range tokens {
+		g.approveERC20(token, router)
 
-	data = g.callContractView(tokenA, uniswap.UniswapV2ERC20ABI, "balanceOf", g.FaucetAccount.Address)
-	fmt.Println("Token A balance: ", data[0].(*big.Int).String())
-	data = g.callContractView(tokenA, uniswap.UniswapV2ERC20ABI, "allowance", g.FaucetAccount.Address, router)
-	fmt.Println("Token A allowance: ", data[0].(*big.Int).String())
-	data = g.callContractView(tokenB, uniswap.UniswapV2ERC20ABI, "balanceOf", g.FaucetAccount.Address)
-	fmt.Println("Token B balance: ", data[0].(*big.Int).String())
-	data = g.callContractView(tokenB, uniswap.UniswapV2ERC20ABI, "allowance", g.FaucetAccount.Address, router)
-	fmt.Println("Token B allowance: ", data[0].(*big.Int).String())
+		data = g.callContractView(token, uniswap.UniswapV2ERC20ABI, "balanceOf", g.FaucetAccount.Address)
+		fmt.Printf("Token %d balance: %s\n", i, data[0].(*big.Int).String())
+		data = g.callContractView(token, uniswap.UniswapV2ERC20ABI, "allowance", g.FaucetAccount.Address, router)
+		fmt.Printf("Token %d allowance: %s\n", i, data[0].(*big.Int).String())
+	}
 
-	g.executeContractFunction(uniswapCreatePairGasLimit, factory, uniswap.UniswapV2FactoryABI, "createPair", tokenA, tokenB)
-	data = g.callContractView(factory, uniswap.UniswapV2FactoryABI, "getPair", tokenA, tokenB)
-	fmt.Println("Pair address: ", data[0].(common.Address).Hex())
+	for i := 0; i < w.pairCount; i++ {
+		tokenA, tokenB := tokens[i], tokens[(i+1)%w.tokenCount]
 
-	var tx *types.Transaction
-	var ethCallTx ethereum.CallMsg
-	var estimateGas uint64
+		g.executeContractFunction(uniswapCreatePairGasLimit, factory, uniswap.UniswapV2FactoryABI, "createPair", tokenA, tokenB)
+		data = g.callContractView(factory, uniswap.UniswapV2FactoryABI, "getPair", tokenA, tokenB)
+		fmt.Printf("Pair %d address: %s\n", i, data[0].(common.Address).Hex())
 
-	fmt.Println("Add liquidity")
+		fmt.Println("Add liquidity")
 
-	g.executeContractFunction(uniswapCreatePairGasLimit, router, uniswap.UniswapV2RouterABI, "addLiquidity",
-		tokenA, tokenB, big.NewInt(1000000000), big.NewInt(1000000000), big.NewInt(0), big.NewInt(0), g.FaucetAccount.Address,
-		big.NewInt(time.Now().Unix()+15*60))
-
-	var mutex sync.Mutex
-	ch := make(chan error)
+		g.executeContractFunction(uniswapCreatePairGasLimit, router, uniswap.UniswapV2RouterABI, "addLiquidity",
+			tokenA, tokenB, big.NewInt(1000000000), big.NewInt(1000000000), big.NewInt(0), big.NewInt(0), g.FaucetAccount.Address,
+			big.NewInt(time.Now().Unix()+15*60))
+	}
 
-	sender := g.Senders[0]
-	path := []common.Address{
-		common.HexToAddress(tokenA.Hex()),
-		common.HexToAddress(tokenB.Hex()),
+	w.router = router
+	starts := w.routeStarts()
+	w.routes = make([][]common.Address, len(starts))
+	for r, start := range starts {
+		path := make([]common.Address, 0, w.hops+1)
+		for j := 0; j <= w.hops; j++ {
+			path = append(path, tokens[(start+j)%w.tokenCount])
+		}
+		w.routes[r] = path
 	}
-	deadline := big.NewInt(time.Now().Unix() + 15*60)
+	fmt.Println("Routes:", len(w.routes), "of", w.hops, "hops, assigned", w.assign)
+	if w.assign == uniswapAssignDisjoint && len(g.Senders) > len(w.routes) {
+		log.Printf("Warning: %d senders share %d disjoint routes, tokens=%d,pairs=%d give every sender its own",
+			len(g.Senders), len(w.routes), len(g.Senders)*w.hops+1, len(g.Senders)*w.hops)
+	}
+
+	w.deadline = big.NewInt(time.Now().Unix() + 15*60)
+
+	var tx *types.Transaction
+	var ethCallTx ethereum.CallMsg
 
+	sender := g.Senders[0]
 	tx = GenerateContractCallingTx(
 		sender.PrivateKey,
 		router.Hex(),
 		0,
+		g.TxType(),
 		g.ChainID,
-		g.GasPrice,
+		g.Fees,
 		uniswapSwapGasLimit,
 		uniswap.UniswapV2RouterABI,
 		"swapExactTokensForTokens",
 		big.NewInt(1000),
 		big.NewInt(0),
-		path,
+		w.routes[0],
 		sender.Address,
-		deadline,
+		w.deadline,
 	)
 	ethCallTx = ConvertLegacyTxToCallMsg(tx, sender.Address)
-	estimateGas = g.estimateGas(ethCallTx)
-	estimateGas = (uint64)(1.2 * float64(estimateGas))
-
-	fmt.Println("Estimated gas:", estimateGas)
-
-	for index, sender := range g.Senders {
-		go func(index int, sender *account.Account) {
-			txs := types.Transactions{}
-			for range g.Recipients {
-				tx := GenerateContractCallingTx(
-					sender.PrivateKey,
-					router.Hex(),
-					sender.GetNonce(),
-					g.ChainID,
-					g.GasPrice,
-					estimateGas,
-					uniswap.UniswapV2RouterABI,
-					"swapExactTokensForTokens",
-					big.NewInt(1000),
-					big.NewInt(0),
-					path,
-					sender.Address,
-					deadline,
-				)
-				txs = append(txs, tx)
-			}
-
-			mutex.Lock()
-			txsMap[index] = txs
-			mutex.Unlock()
-			ch <- nil
-		}(index, sender)
-	}
-
-	for i := 0; i < len(g.Senders); i++ {
-		msg := <-ch
-		if msg != nil {
-			return txsMap, msg
-		}
+	w.estimateGas = g.estimateGas(ethCallTx)
+	w.estimateGas = (uint64)(1.2 * float64(w.estimateGas))
+
+	fmt.Println("Estimated gas:", w.estimateGas)
+
+	w.rngs = make([]*rand.Rand, len(g.Senders))
+	for i := range w.rngs {
+		w.rngs[i] = rand.New(rand.NewSource(int64(i)))
 	}
+	w.labels = make(map[common.Hash]string)
 
-	if g.ShouldPersist {
-		err := g.Store.PersistTxsMap(txsMap)
-		if err != nil {
-			return txsMap, err
-		}
+	return nil
+}
+
+func (w *uniswapWorkload) GenerateTx(g *Generator, senderIndex int, sender *account.Account, seq int) (*types.Transaction, error) {
+	route := 0
+	switch w.assign {
+	case uniswapAssignDisjoint:
+		route = senderIndex % len(w.routes)
+	case uniswapAssignRandom:
+		route = w.rngs[senderIndex].Intn(len(w.routes))
+	}
+
+	tx := GenerateContractCallingTx(
+		sender.PrivateKey,
+		w.router.Hex(),
+		sender.GetNonce(),
+		g.TxType(),
+		g.ChainID,
+		g.Fees,
+		w.estimateGas,
+		uniswap.UniswapV2RouterABI,
+		"swapExactTokensForTokens",
+		big.NewInt(1000),
+		big.NewInt(0),
+		w.routes[route],
+		sender.Address,
+		w.deadline,
+	)
+
+	if len(w.routes) > 1 {
+		w.mutex.Lock()
+		w.labels[tx.Hash()] = fmt.Sprintf("route-%d", route)
+		w.mutex.Unlock()
 	}
 
-	return txsMap, nil
+	return tx, nil
+}
+
+func (w *uniswapWorkload) Labels() map[common.Hash]string {
+	return w.labels
 }
 
 type Contract struct {
@@ -157,29 +278,32 @@ type Contract struct {
 }
 
 func ReadContract(filePath string) (string, string) {
-	file, err := os.Open(filePath)
+	abiJSON, bin, err := readContract(filePath)
 	if err != nil {
-		log.Fatalf("Failed to open file: %v", err)
+		log.Fatalf("Failed to read contract: %v", err)
 	}
-	defer file.Close()
+	return abiJSON, bin
+}
 
-	fileData, err := io.ReadAll(file)
+// readContract returns the ABI and the bytecode of a Hardhat style artifact.
+func readContract(filePath string) (string, string, error) {
+	fileData, err := os.ReadFile(filePath)
 	if err != nil {
-		log.Fatalf("Failed to read file: %v", err)
+		return "", "", fmt.Errorf("failed to read file: %w", err)
 	}
 
 	var contract Contract
 	err = json.Unmarshal(fileData, &contract)
 	if err != nil {
-		log.Fatalf("Failed to unmarshal JSON: %v", err)
+		return "", "", fmt.Errorf("failed to unmarshal JSON of %s: %w", filePath, err)
 	}
 
 	abiJSON, err := json.Marshal(contract.Abi)
 	if err != nil {
-		log.Fatalf("Failed to marshal ABI: %v", err)
+		return "", "", fmt.Errorf("failed to marshal ABI of %s: %w", filePath, err)
 	}
 
-	return string(abiJSON), contract.Bytecode
+	return string(abiJSON), contract.Bytecode, nil
 }
 
 func (g *Generator) prepareContractUniswap() (common.Address, common.Address) {
@@ -0,0 +1,115 @@
+package generator
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/0glabs/evmchainbench/lib/account"
+	"github.com/0glabs/evmchainbench/lib/contract_meta_data/weth"
+)
+
+func init() {
+	Register(func() Workload { return &wethWorkload{} })
+}
+
+// wethWorkload wraps the native token and unwraps it back: every sender
+// alternates deposits sending `amount` with withdrawals of the same amount,
+// so every call moves value between the sender and the contract and updates
+// the token balance of the sender.
+type wethWorkload struct {
+	amount *big.Int
+
+	contractAddress common.Address
+	depositGas      uint64
+	withdrawGas     uint64
+
+	mutex  sync.Mutex
+	labels map[common.Hash]string
+}
+
+func (w *wethWorkload) Name() string {
+	return "weth"
+}
+
+func (w *wethWorkload) Describe() string {
+	return "WETH style deposits with value alternating with withdrawals of the same amount. Options: amount=gwei wrapped per deposit (default 10000)"
+}
+
+func (w *wethWorkload) Validate(params Params) error {
+	err := params.Check("amount")
+	if err != nil {
+		return err
+	}
+
+	w.amount, err = parseGwei(params.String("amount", "10000"))
+	if err != nil {
+		return fmt.Errorf("amount: %w", err)
+	}
+
+	return nil
+}
+
+func (w *wethWorkload) Prepare(g *Generator) error {
+	var err error
+	w.contractAddress, err = g.deployContract(wethContractGasLimit, weth.WETHBin, weth.WETHABI)
+	if err != nil {
+		return err
+	}
+	fmt.Println("WETH contract:", w.contractAddress.Hex())
+
+	g.prepareSenders()
+
+	// The senders only deposit into an empty balance and withdraw all of
+	// it. The faucet is funded already, unlike the fresh senders, so it
+	// deposits once to estimate both.
+	w.depositGas = w.estimate(g, w.amount, "deposit")
+	g.executePayableContractFunction(wethCallGasLimit, w.amount, w.contractAddress, weth.WETHABI, "deposit")
+	w.withdrawGas = w.estimate(g, big.NewInt(0), "withdraw", w.amount)
+	fmt.Printf("WETH: amount %s gwei, estimated gas of deposit: %d withdraw: %d\n",
+		formatGwei(new(big.Float).SetInt(w.amount)), w.depositGas, w.withdrawGas)
+
+	w.labels = make(map[common.Hash]string)
+
+	return nil
+}
+
+func (w *wethWorkload) estimate(g *Generator, value *big.Int, method string, args ...interface{}) uint64 {
+	faucet := g.FaucetAccount
+	tx := GeneratePayableContractCallingTx(faucet.PrivateKey, w.contractAddress.Hex(), 0, g.TxType(), g.ChainID, g.Fees,
+		wethCallGasLimit, value, weth.WETHABI, method, args...)
+	return g.estimateGas(ConvertLegacyTxToCallMsg(tx, faucet.Address))
+}
+
+func (w *wethWorkload) GenerateTx(g *Generator, senderIndex int, sender *account.Account, seq int) (*types.Transaction, error) {
+	// the nonces of a sender keep every withdrawal behind its deposit
+	var tx *types.Transaction
+	label := "deposit"
+	if seq%2 == 0 {
+		tx = GeneratePayableContractCallingTx(sender.PrivateKey, w.contractAddress.Hex(), sender.GetNonce(), g.TxType(), g.ChainID, g.Fees,
+			w.depositGas, w.amount, weth.WETHABI, "deposit")
+	} else {
+		label = "withdraw"
+		tx = GenerateContractCallingTx(sender.PrivateKey, w.contractAddress.Hex(), sender.GetNonce(), g.TxType(), g.ChainID, g.Fees,
+			w.withdrawGas, weth.WETHABI, "withdraw", w.amount)
+	}
+
+	w.mutex.Lock()
+	w.labels[tx.Hash()] = label
+	w.mutex.Unlock()
+
+	return tx, nil
+}
+
+func (w *wethWorkload) Labels() map[common.Hash]string {
+	return w.labels
+}
+
+// AccessList knows the layout of the contract: the balances in the mapping
+// at slot 0. Withdrawals send the value to the sender, which is warm already.
+func (w *wethWorkload) AccessList(tx *types.Transaction, from common.Address) types.AccessList {
+	return types.AccessList{{Address: *tx.To(), StorageKeys: []common.Hash{mappingSlot(common.BytesToHash(from.Bytes()), 0)}}}
+}
@@ -0,0 +1,127 @@
+package generator
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/0glabs/evmchainbench/lib/account"
+)
+
+const (
+	InvalidBadSignature       = "bad-signature"
+	InvalidWrongChainID       = "wrong-chain-id"
+	InvalidInsufficientFunds  = "insufficient-balance"
+	InvalidIntrinsicGasTooLow = "intrinsic-gas"
+	InvalidOversize           = "oversize"
+
+	// geth drops txs larger than 128KB
+	invalidOversizeBytes = 132 * 1024
+)
+
+var invalidKinds = []string{InvalidBadSignature, InvalidWrongChainID, InvalidInsufficientFunds, InvalidIntrinsicGasTooLow, InvalidOversize}
+
+// InvalidMix injects transactions which the node must reject into the
+// streams of the senders. They reuse the nonce of the next valid tx, so the
+// valid ones are not affected when they are rejected.
+type InvalidMix struct {
+	ratio float64
+	kinds []string
+}
+
+// ParseInvalidMix accepts the fraction of invalid txs among all the txs sent
+// and a comma separated list of kinds, "all" or empty for every kind.
+func ParseInvalidMix(ratio float64, kinds string) (*InvalidMix, error) {
+	if ratio == 0 {
+		return nil, nil
+	}
+	if ratio < 0 || ratio >= 1 {
+		return nil, fmt.Errorf("the ratio of invalid txs must be at least 0 and below 1, got %v", ratio)
+	}
+
+	m := &InvalidMix{ratio: ratio}
+	if kinds == "" || kinds == "all" {
+		m.kinds = invalidKinds
+		return m, nil
+	}
+	for _, kind := range strings.Split(kinds, ",") {
+		kind = strings.TrimSpace(kind)
+		found := false
+		for _, k := range invalidKinds {
+			if k == kind {
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown invalid tx kind %q, expected one of %s", kind, strings.Join(invalidKinds, ", "))
+		}
+		m.kinds = append(m.kinds, kind)
+	}
+	return m, nil
+}
+
+func (m *InvalidMix) String() string {
+	return fmt.Sprintf("%.2f%% of %s", m.ratio*100, strings.Join(m.kinds, ", "))
+}
+
+// perValid is the number of invalid txs to send along with every valid one.
+func (m *InvalidMix) perValid() float64 {
+	return m.ratio / (1 - m.ratio)
+}
+
+// Tx returns an invalid transfer of the given kind, the n-th invalid tx of
+// the sender.
+func (m *InvalidMix) Tx(g *Generator, sender *account.Account, recipient string, n int) (*types.Transaction, string, error) {
+	kind := m.kinds[n%len(m.kinds)]
+	to := common.HexToAddress(recipient)
+	// the next valid tx uses this nonce too
+	nonce := sender.Nonce
+	txType := g.TxType()
+	signer := types.LatestSignerForChainID(g.ChainID)
+	// the value keeps invalid txs sharing a nonce apart
+	value := big.NewInt(int64(n + 1))
+
+	var tx *types.Transaction
+	var err error
+	switch kind {
+	case InvalidBadSignature:
+		tx, err = types.SignTx(NewTypedTx(txType, g.ChainID, nonce, &to, value, simpleTransferGasLimit, g.Fees, nil), signer, sender.PrivateKey)
+		if err != nil {
+			return nil, kind, err
+		}
+		// the malleable twin of the signature, with the high s EIP-2 forbids
+		v, r, s := tx.RawSignatureValues()
+		sig := make([]byte, crypto.SignatureLength)
+		r.FillBytes(sig[:32])
+		new(big.Int).Sub(crypto.S256().Params().N, s).FillBytes(sig[32:64])
+		sig[64] = 1 - byte(signatureParity(tx, v))
+		tx, err = tx.WithSignature(signer, sig)
+	case InvalidWrongChainID:
+		chainID := new(big.Int).Add(g.ChainID, big.NewInt(1))
+		tx, err = types.SignTx(NewTypedTx(txType, chainID, nonce, &to, value, simpleTransferGasLimit, g.Fees, nil),
+			types.LatestSignerForChainID(chainID), sender.PrivateKey)
+	case InvalidInsufficientFunds:
+		// far above the funds of any sender
+		value.Add(value, new(big.Int).Exp(big.NewInt(10), big.NewInt(30), nil))
+		tx, err = types.SignTx(NewTypedTx(txType, g.ChainID, nonce, &to, value, simpleTransferGasLimit, g.Fees, nil), signer, sender.PrivateKey)
+	case InvalidIntrinsicGasTooLow:
+		tx, err = types.SignTx(NewTypedTx(txType, g.ChainID, nonce, &to, value, simpleTransferGasLimit-1, g.Fees, nil), signer, sender.PrivateKey)
+	case InvalidOversize:
+		data := make([]byte, invalidOversizeBytes)
+		tx, err = types.SignTx(NewTypedTx(txType, g.ChainID, nonce, &to, value, simpleTransferGasLimit, g.Fees, data), signer, sender.PrivateKey)
+	}
+	return tx, kind, err
+}
+
+// signatureParity returns the y parity of a signature from its V value.
+func signatureParity(tx *types.Transaction, v *big.Int) uint64 {
+	if tx.Type() != types.LegacyTxType {
+		return v.Uint64()
+	}
+	// EIP-155: v = chainID*2 + 35 + parity
+	return (v.Uint64() - 35) % 2
+}
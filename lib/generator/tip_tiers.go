@@ -0,0 +1,108 @@
+package generator
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/0glabs/evmchainbench/lib/account"
+)
+
+// tipRandomBuckets is the number of tiers random tips are reported in
+const tipRandomBuckets = 4
+
+// TipTiers gives the transactions of every group of senders their own
+// priority fee, or every transaction a random one, so that the inclusion of
+// the tiers can be compared.
+type TipTiers struct {
+	tips []*big.Int
+	// random tips are drawn from [min, max]
+	random   bool
+	min, max *big.Int
+}
+
+// ParseTipTiers accepts tips in gwei, e.g. "1,2,5,10" with sender i bidding
+// the tip i modulo 4, or "random=1:10" for random tips.
+func ParseTipTiers(spec string) (*TipTiers, error) {
+	if spec == "" {
+		return nil, nil
+	}
+
+	t := &TipTiers{}
+	if bounds, ok := strings.CutPrefix(spec, "random="); ok {
+		low, high, ok := strings.Cut(bounds, ":")
+		if !ok {
+			return nil, fmt.Errorf("expected random=min:max, got %q", spec)
+		}
+		var err error
+		t.min, err = parseGwei(low)
+		if err != nil {
+			return nil, err
+		}
+		t.max, err = parseGwei(high)
+		if err != nil {
+			return nil, err
+		}
+		if t.max.Cmp(t.min) <= 0 {
+			return nil, fmt.Errorf("empty tip range %q", bounds)
+		}
+		t.random = true
+		return t, nil
+	}
+
+	for _, part := range strings.Split(spec, ",") {
+		tip, err := parseGwei(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		t.tips = append(t.tips, tip)
+	}
+	return t, nil
+}
+
+func (t *TipTiers) String() string {
+	if t.random {
+		return fmt.Sprintf("random %s gwei", t.tierName(t.min, t.max))
+	}
+	names := make([]string, len(t.tips))
+	for i, tip := range t.tips {
+		names[i] = formatGwei(new(big.Float).SetInt(tip))
+	}
+	return strings.Join(names, ", ") + " gwei"
+}
+
+// Tip returns the tip of a transaction of the sender and the name of its tier.
+// rng is only used for random tips.
+func (t *TipTiers) Tip(senderIndex int, rng *rand.Rand) (*big.Int, string) {
+	if !t.random {
+		tip := t.tips[senderIndex%len(t.tips)]
+		return tip, fmt.Sprintf("tip %s gwei", formatGwei(new(big.Float).SetInt(tip)))
+	}
+
+	span := new(big.Int).Sub(t.max, t.min)
+	tip := new(big.Int).Rand(rng, new(big.Int).Add(span, big.NewInt(1)))
+	bucket := new(big.Int).Div(new(big.Int).Mul(tip, big.NewInt(tipRandomBuckets)), new(big.Int).Add(span, big.NewInt(1))).Int64()
+	tip.Add(tip, t.min)
+
+	low := new(big.Int).Add(t.min, new(big.Int).Div(new(big.Int).Mul(span, big.NewInt(bucket)), big.NewInt(tipRandomBuckets)))
+	high := new(big.Int).Add(t.min, new(big.Int).Div(new(big.Int).Mul(span, big.NewInt(bucket+1)), big.NewInt(tipRandomBuckets)))
+	return tip, "tip " + t.tierName(low, high) + " gwei"
+}
+
+func (t *TipTiers) tierName(low, high *big.Int) string {
+	return formatGwei(new(big.Float).SetInt(low)) + "-" + formatGwei(new(big.Float).SetInt(high))
+}
+
+// tipFees adds the tip to the price of the generator, so every tier keeps
+// the same room for the base fee whatever its tip.
+func tipFees(fees *Fees, tip *big.Int) *Fees {
+	return &Fees{GasPrice: new(big.Int).Add(fees.GasPrice, tip), GasTipCap: tip}
+}
+
+// withTip returns tx signed again with the given tip.
+func (g *Generator) withTip(tx *types.Transaction, tip *big.Int, sender *account.Account) (*types.Transaction, error) {
+	return types.SignTx(types.NewTx(withFees(tx, tipFees(g.Fees, tip))), types.LatestSignerForChainID(g.ChainID), sender.PrivateKey)
+}
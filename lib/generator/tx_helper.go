@@ -10,30 +10,15 @@ import (
 	abipkg "github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
 )
 
-func GenerateSimpleTransferTx(privateKey *ecdsa.PrivateKey, recipient string, nonce uint64, chainID, gasPrice, value *big.Int, eip1559 bool) (*types.Transaction, error) {
+func GenerateSimpleTransferTx(privateKey *ecdsa.PrivateKey, recipient string, nonce uint64, txType uint8, chainID *big.Int, fees *Fees, value *big.Int) (*types.Transaction, error) {
 	toAddress := common.HexToAddress(recipient)
 
-	var signedTx *types.Transaction
-	var err error
-	if eip1559 {
-		tx := types.NewTx(&types.DynamicFeeTx{
-			ChainID:   chainID,
-			Nonce:     nonce,
-			To:        &toAddress,
-			Value:     value,
-			GasFeeCap: gasPrice,
-			GasTipCap: gasPrice,
-			Gas:       simpleTransferGasLimit,
-			Data:      nil,
-		})
-		signedTx, err = types.SignTx(tx, types.NewLondonSigner(chainID), privateKey)
-	} else {
-		tx := types.NewTransaction(nonce, toAddress, value, simpleTransferGasLimit, gasPrice, nil)
-		signedTx, err = types.SignTx(tx, types.NewEIP155Signer(chainID), privateKey)
-	}
-
+	tx := NewTypedTx(txType, chainID, nonce, &toAddress, value, simpleTransferGasLimit, fees, nil)
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(chainID), privateKey)
 	if err != nil {
 		return &types.Transaction{}, err
 	}
@@ -41,7 +26,62 @@ func GenerateSimpleTransferTx(privateKey *ecdsa.PrivateKey, recipient string, no
 	return signedTx, nil
 }
 
-func GenerateContractCreationTx(privateKey *ecdsa.PrivateKey, nonce uint64, chainID, gasPrice *big.Int, gasLimit uint64, contractBin, contractABI string, args ...interface{}) (*types.Transaction, error) {
+// GenerateBlobTx builds a signed EIP-4844 transaction carrying the blobs of
+// the sidecar.
+func GenerateBlobTx(privateKey *ecdsa.PrivateKey, recipient string, nonce uint64, chainID *big.Int, fees *Fees, blobFeeCap *big.Int, sidecar *types.BlobTxSidecar) (*types.Transaction, error) {
+	tx := types.NewTx(&types.BlobTx{
+		ChainID:    uint256.MustFromBig(chainID),
+		Nonce:      nonce,
+		GasTipCap:  uint256.MustFromBig(fees.GasTipCap),
+		GasFeeCap:  uint256.MustFromBig(fees.GasPrice),
+		Gas:        simpleTransferGasLimit,
+		To:         common.HexToAddress(recipient),
+		Value:      new(uint256.Int),
+		BlobFeeCap: uint256.MustFromBig(blobFeeCap),
+		BlobHashes: sidecar.BlobHashes(),
+		Sidecar:    sidecar,
+	})
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), privateKey)
+}
+
+// NewTypedTx builds an unsigned transaction in the given envelope with the
+// given price.
+func NewTypedTx(txType uint8, chainID *big.Int, nonce uint64, to *common.Address, value *big.Int, gasLimit uint64, fees *Fees, data []byte) *types.Transaction {
+	switch txType {
+	case types.AccessListTxType:
+		return types.NewTx(&types.AccessListTx{
+			ChainID:  chainID,
+			Nonce:    nonce,
+			GasPrice: fees.GasPrice,
+			Gas:      gasLimit,
+			To:       to,
+			Value:    value,
+			Data:     data,
+		})
+	case types.DynamicFeeTxType:
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     nonce,
+			GasTipCap: fees.GasTipCap,
+			GasFeeCap: fees.GasPrice,
+			Gas:       gasLimit,
+			To:        to,
+			Value:     value,
+			Data:      data,
+		})
+	default:
+		return types.NewTx(&types.LegacyTx{
+			Nonce:    nonce,
+			GasPrice: fees.GasPrice,
+			Gas:      gasLimit,
+			To:       to,
+			Value:    value,
+			Data:     data,
+		})
+	}
+}
+
+func GenerateContractCreationTx(privateKey *ecdsa.PrivateKey, nonce uint64, txType uint8, chainID *big.Int, fees *Fees, gasLimit uint64, contractBin, contractABI string, args ...interface{}) (*types.Transaction, error) {
 	bytecode, err := hex.DecodeString(contractBin)
 	if err != nil {
 		return &types.Transaction{}, err
@@ -62,15 +102,9 @@ func GenerateContractCreationTx(privateKey *ecdsa.PrivateKey, nonce uint64, chai
 
 	}
 
-	tx := types.NewContractCreation(
-		nonce,
-		big.NewInt(0),
-		gasLimit,
-		gasPrice,
-		bytecode,
-	)
+	tx := NewTypedTx(txType, chainID, nonce, nil, big.NewInt(0), gasLimit, fees, bytecode)
 
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(chainID), privateKey)
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(chainID), privateKey)
 	if err != nil {
 		return &types.Transaction{}, err
 	}
@@ -80,16 +114,23 @@ func GenerateContractCreationTx(privateKey *ecdsa.PrivateKey, nonce uint64, chai
 
 func ConvertLegacyTxToCallMsg(tx *types.Transaction, from common.Address) ethereum.CallMsg {
 	return ethereum.CallMsg{
-		From:     from,
-		To:       tx.To(),
-		Gas:      tx.Gas(),
-		GasPrice: tx.GasPrice(),
-		Value:    tx.Value(),
-		Data:     tx.Data(),
+		From:       from,
+		To:         tx.To(),
+		Gas:        tx.Gas(),
+		GasPrice:   tx.GasPrice(),
+		Value:      tx.Value(),
+		Data:       tx.Data(),
+		AccessList: tx.AccessList(),
 	}
 }
 
-func GenerateContractCallingTx(privateKey *ecdsa.PrivateKey, contractAddress string, nonce uint64, chainID, gasPrice *big.Int, gasLimit uint64, contractABI, method string, args ...interface{}) *types.Transaction {
+func GenerateContractCallingTx(privateKey *ecdsa.PrivateKey, contractAddress string, nonce uint64, txType uint8, chainID *big.Int, fees *Fees, gasLimit uint64, contractABI, method string, args ...interface{}) *types.Transaction {
+	return GeneratePayableContractCallingTx(privateKey, contractAddress, nonce, txType, chainID, fees, gasLimit, big.NewInt(0), contractABI, method, args...)
+}
+
+// GeneratePayableContractCallingTx is GenerateContractCallingTx sending value
+// along with the call.
+func GeneratePayableContractCallingTx(privateKey *ecdsa.PrivateKey, contractAddress string, nonce uint64, txType uint8, chainID *big.Int, fees *Fees, gasLimit uint64, value *big.Int, contractABI, method string, args ...interface{}) *types.Transaction {
 	abi, err := abipkg.JSON(strings.NewReader(contractABI))
 	if err != nil {
 		panic(err)
@@ -101,19 +142,28 @@ func GenerateContractCallingTx(privateKey *ecdsa.PrivateKey, contractAddress str
 	}
 
 	toAddress := common.HexToAddress(contractAddress)
-	tx := types.NewTransaction(
-		nonce,
-		toAddress,
-		big.NewInt(0),
-		gasLimit,
-		gasPrice,
-		data,
-	)
-
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(chainID), privateKey)
+	tx := NewTypedTx(txType, chainID, nonce, &toAddress, value, gasLimit, fees, data)
+
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(chainID), privateKey)
 	if err != nil {
 		panic(err)
 	}
 
 	return signedTx
 }
+
+// seedBytes expands the seed into n bytes, none of which is zero so that
+// the calldata cost does not depend on the seed.
+func seedBytes(seed []byte, n int) []byte {
+	data := make([]byte, 0, n+32)
+	for len(data) < n {
+		seed = crypto.Keccak256(seed)
+		data = append(data, seed...)
+	}
+	for i := range data {
+		if data[i] == 0 {
+			data[i] = 1
+		}
+	}
+	return data[:n]
+}
@@ -0,0 +1,344 @@
+package generator
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/0glabs/evmchainbench/lib/account"
+)
+
+// Workload describes one kind of benchmark traffic. A fresh instance is
+// created for every run, so implementations may keep the state produced by
+// Prepare (deployed contracts, estimated gas, ...) in their own fields.
+type Workload interface {
+	// Name is the identifier used by the --tx-type flag.
+	Name() string
+	// Describe returns a short, human readable summary of the workload and
+	// the parameters it accepts.
+	Describe() string
+	// Validate checks the workload parameters and records them. It is called
+	// before any interaction with the chain.
+	Validate(params Params) error
+	// Prepare funds senders, deploys contracts and does any other setup
+	// needed before transactions can be generated.
+	Prepare(g *Generator) error
+	// GenerateTx builds the seq-th transaction of the given sender. It is
+	// called concurrently for different senders.
+	GenerateTx(g *Generator, senderIndex int, sender *account.Account, seq int) (*types.Transaction, error)
+}
+
+// Labeler is implemented by workloads that emit several kinds of
+// transactions and want them reported separately in the run summary.
+type Labeler interface {
+	// Labels maps the hash of every generated transaction to its kind.
+	Labels() map[common.Hash]string
+}
+
+// FailureExpecter is implemented by labelling workloads some transactions of
+// which are meant to fail on chain. The receipt of every labelled transaction
+// is then checked against the expected status.
+type FailureExpecter interface {
+	// ExpectFailure tells whether the transactions of a kind must fail.
+	ExpectFailure(label string) bool
+}
+
+// LogEmitter is implemented by workloads every transaction of which emits the
+// same number of logs, so that the listener can check that eth_getLogs returns
+// all the logs of every block.
+type LogEmitter interface {
+	LogsPerTx() int
+}
+
+var (
+	registryMutex sync.RWMutex
+	registry      = make(map[string]func() Workload)
+)
+
+// Register makes a workload available under its name. It is meant to be
+// called from the init function of the file implementing the workload.
+func Register(factory func() Workload) {
+	name := factory().Name()
+
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("workload %q is registered twice", name))
+	}
+	registry[name] = factory
+}
+
+// NewWorkload looks up a registered workload and validates its parameters.
+func NewWorkload(name string, params Params) (Workload, error) {
+	registryMutex.RLock()
+	factory, ok := registry[name]
+	registryMutex.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("transaction type %q is not valid, available types: %s", name, strings.Join(WorkloadNames(), ", "))
+	}
+
+	w := factory()
+	err := w.Validate(params)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameters for %q: %w", name, err)
+	}
+
+	return w, nil
+}
+
+// Workloads returns a fresh instance of every registered workload, sorted by name.
+func Workloads() []Workload {
+	names := WorkloadNames()
+	workloads := make([]Workload, 0, len(names))
+
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	for _, name := range names {
+		workloads = append(workloads, registry[name]())
+	}
+
+	return workloads
+}
+
+// WorkloadNames returns the names of all registered workloads, sorted.
+func WorkloadNames() []string {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Generate prepares the workload and builds len(g.Recipients) transactions
+// for every sender.
+func (g *Generator) Generate(w Workload) (map[int]types.Transactions, error) {
+	txsMap := make(map[int]types.Transactions)
+
+	err := validateAccessList(g.AccessList, w, g.TxTypes)
+	if err != nil {
+		return txsMap, err
+	}
+
+	if g.ShouldPersist {
+		defer g.Store.PersistPrepareTxs()
+	}
+
+	err = w.Prepare(g)
+	if err != nil {
+		return txsMap, err
+	}
+
+	var mutex sync.Mutex
+	ch := make(chan error)
+	withAccessList := g.AccessList != "" && g.AccessList != AccessListNone
+	accessListReverted := 0
+	// the hashes of the txs signed again with a tip tier or an access list
+	rehashed := make(map[common.Hash]common.Hash)
+	if g.TipTiers != nil {
+		g.TxTiers = make(map[common.Hash]string)
+	}
+	if g.Invalid != nil {
+		g.InvalidTxs = make(map[common.Hash]string)
+	}
+
+	log.Default().Println("Generating", w.Name(), "transactions...")
+	for index, sender := range g.Senders {
+		go func(index int, sender *account.Account) {
+			var builder *accessListBuilder
+			if withAccessList {
+				var err error
+				builder, err = g.newAccessListBuilder(w)
+				if err != nil {
+					ch <- err
+					return
+				}
+				defer builder.Close()
+			}
+
+			txs := types.Transactions{}
+			hashes := make(map[common.Hash]common.Hash)
+			tiers := make(map[common.Hash]string)
+			rng := rand.New(rand.NewSource(int64(index)))
+			invalid := make(map[common.Hash]string)
+			invalidDue := 0.0
+			for seq := range g.Recipients {
+				if g.Invalid != nil {
+					for invalidDue += g.Invalid.perValid(); invalidDue >= 1; invalidDue-- {
+						tx, kind, err := g.Invalid.Tx(g, sender, g.Recipients[seq], len(invalid))
+						if err != nil {
+							ch <- err
+							return
+						}
+						invalid[tx.Hash()] = kind
+						txs = append(txs, tx)
+					}
+				}
+
+				tx, err := w.GenerateTx(g, index, sender, seq)
+				if err != nil {
+					ch <- err
+					return
+				}
+				generated := tx.Hash()
+				var tier string
+				if g.TipTiers != nil {
+					var tip *big.Int
+					tip, tier = g.TipTiers.Tip(index, rng)
+					tx, err = g.withTip(tx, tip, sender)
+					if err != nil {
+						ch <- err
+						return
+					}
+				}
+				if builder != nil {
+					tx, err = builder.Add(tx, sender)
+					if err != nil {
+						ch <- err
+						return
+					}
+				}
+				if tx.Hash() != generated {
+					hashes[generated] = tx.Hash()
+				}
+				if tier != "" {
+					tiers[tx.Hash()] = tier
+				}
+				txs = append(txs, tx)
+			}
+
+			mutex.Lock()
+			txsMap[index] = txs
+			if builder != nil {
+				accessListReverted += builder.reverted
+			}
+			for from, to := range hashes {
+				rehashed[from] = to
+			}
+			for hash, tier := range tiers {
+				g.TxTiers[hash] = tier
+			}
+			for hash, kind := range invalid {
+				g.InvalidTxs[hash] = kind
+			}
+			mutex.Unlock()
+			ch <- nil
+		}(index, sender)
+	}
+
+	for i := 0; i < len(g.Senders); i++ {
+		msg := <-ch
+		if msg != nil {
+			return txsMap, msg
+		}
+	}
+
+	if accessListReverted > 0 {
+		log.Printf("Warning: %d of %d txs reverted when eth_createAccessList simulated them against the current state, "+
+			"their access lists may miss the state accessed after the revert", accessListReverted, len(g.Senders)*len(g.Recipients))
+	}
+
+	if labeler, ok := w.(Labeler); ok && len(rehashed) > 0 {
+		labels := labeler.Labels()
+		for from, to := range rehashed {
+			if label, ok := labels[from]; ok {
+				labels[to] = label
+				delete(labels, from)
+			}
+		}
+	}
+
+	if g.ShouldPersist {
+		err := g.Store.PersistTxsMap(txsMap)
+		if err != nil {
+			return txsMap, err
+		}
+	}
+
+	return txsMap, nil
+}
+
+// Params holds the workload specific key=value options given on the
+// command line.
+type Params map[string]string
+
+// Check returns an error if a parameter is not one of the known keys.
+func (p Params) Check(known ...string) error {
+	for key := range p {
+		found := false
+		for _, k := range known {
+			if k == key {
+				found = true
+				break
+			}
+		}
+		if !found {
+			if len(known) == 0 {
+				return fmt.Errorf("unknown parameter %q, the workload takes no parameters", key)
+			}
+			return fmt.Errorf("unknown parameter %q, known parameters: %s", key, strings.Join(known, ", "))
+		}
+	}
+
+	return nil
+}
+
+func (p Params) String(key, def string) string {
+	if value, ok := p[key]; ok {
+		return value
+	}
+	return def
+}
+
+func (p Params) Int(key string, def int) (int, error) {
+	value, ok := p[key]
+	if !ok {
+		return def, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return def, fmt.Errorf("parameter %q: %w", key, err)
+	}
+	return n, nil
+}
+
+func (p Params) Float(key string, def float64) (float64, error) {
+	value, ok := p[key]
+	if !ok {
+		return def, nil
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return def, fmt.Errorf("parameter %q: %w", key, err)
+	}
+	return f, nil
+}
+
+func (p Params) Bool(key string, def bool) (bool, error) {
+	value, ok := p[key]
+	if !ok {
+		return def, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return def, fmt.Errorf("parameter %q: %w", key, err)
+	}
+	return b, nil
+}
@@ -1,6 +1,7 @@
 package store
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -69,10 +70,55 @@ func (s *Store) LoadTxsMap() (map[int]types.Transactions, error) {
 	return txsMap, nil
 }
 
+// WorkloadInfo records which workload the stored transactions were
+// generated with.
+type WorkloadInfo struct {
+	Name   string            `json:"name"`
+	Params map[string]string `json:"params,omitempty"`
+}
+
+func (s *Store) PersistWorkload(info WorkloadInfo) error {
+	err := os.MkdirAll(s.TxStoreDir, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.workloadFilePath(), data, 0644)
+}
+
+// LoadWorkload returns the recorded workload, or nil for stores written
+// before the workload was recorded.
+func (s *Store) LoadWorkload() (*WorkloadInfo, error) {
+	data, err := os.ReadFile(s.workloadFilePath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var info WorkloadInfo
+	err = json.Unmarshal(data, &info)
+	if err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
 func (s *Store) prepareFilePath() string {
 	return filepath.Join(s.TxStoreDir, "prepare.rlp")
 }
 
+func (s *Store) workloadFilePath() string {
+	return filepath.Join(s.TxStoreDir, "workload.json")
+}
+
 func (s *Store) txsFilePath(index int) string {
 	return filepath.Join(s.TxStoreDir, fmt.Sprintf("transactions-%d.rlp", index))
 }
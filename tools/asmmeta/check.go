@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// checks are the behaviour checks of the contracts by name, registered by the
+// check_<package>.go files.
+var checks = map[string]func(t *tester){}
+
+// listings are all the assembled contracts by name, so that a check can deploy
+// the contracts its contract talks to.
+var listings = map[string]*listing{}
+
+// checkPackage checks the meta data, the ABIs and the behaviour of the
+// contracts of a package, it returns all the failures.
+func checkPackage(dir, metaFile, pkg string, pkgListings []*listing) []error {
+	var errs []error
+	meta, err := os.ReadFile(metaFile)
+	if err != nil {
+		errs = append(errs, err)
+	} else if string(meta) != metaData(pkg, pkgListings) {
+		errs = append(errs, fmt.Errorf("%s is out of date, run make asm-metadata", metaFile))
+	}
+
+	sol, err := readSolidity(dir)
+	if err != nil {
+		return append(errs, err)
+	}
+	for _, l := range pkgListings {
+		parsed, err := abi.JSON(strings.NewReader(l.abi))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s.abi: %w", l.name, err))
+			continue
+		}
+		errs = append(errs, checkDeclarations(l, parsed, sol)...)
+
+		check, ok := checks[l.name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s has no behaviour check", l.name))
+			continue
+		}
+		t := newTester(l, parsed)
+		check(t)
+		errs = append(errs, t.finish()...)
+	}
+	return errs
+}
+
+func readSolidity(dir string) (string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.sol"))
+	if err != nil {
+		return "", err
+	}
+	var sol strings.Builder
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		sol.Write(src)
+	}
+	return sol.String(), nil
+}
+
+// checkDeclarations checks that the listing dispatches every function and
+// emits every event of the ABI, and that the Solidity declares them.
+func checkDeclarations(l *listing, parsed abi.ABI, sol string) []error {
+	sels := map[string]bool{}
+	topics := map[string]bool{}
+	for _, line := range strings.Split(l.src, "\n") {
+		line, _, _ = strings.Cut(line, ";")
+		for _, token := range strings.Fields(line) {
+			if sig, ok := strings.CutPrefix(token, "SEL("); ok {
+				sels[strings.TrimSuffix(sig, ")")] = true
+			}
+			if sig, ok := strings.CutPrefix(token, "TOPIC("); ok {
+				topics[strings.TrimSuffix(sig, ")")] = true
+			}
+		}
+	}
+
+	var errs []error
+	for _, method := range parsed.Methods {
+		if !sels[method.Sig] {
+			errs = append(errs, fmt.Errorf("%s.asm does not dispatch %s", l.name, method.Sig))
+		}
+		declared := regexp.MustCompile(`\bfunction\s+` + method.RawName + `\s*\(|\bpublic\s+` + method.RawName + `\s*;`)
+		if !declared.MatchString(sol) {
+			errs = append(errs, fmt.Errorf("the Solidity of %s does not declare %s", l.name, method.Sig))
+		}
+	}
+	for _, event := range parsed.Events {
+		if !topics[event.Sig] {
+			errs = append(errs, fmt.Errorf("%s.asm does not emit %s", l.name, event.Sig))
+		}
+		if !regexp.MustCompile(`\bevent\s+` + event.RawName + `\s*\(`).MatchString(sol) {
+			errs = append(errs, fmt.Errorf("the Solidity of %s does not declare %s", l.name, event.Sig))
+		}
+	}
+	if parsed.HasFallback() && !regexp.MustCompile(`\bfallback\s*\(`).MatchString(sol) {
+		errs = append(errs, fmt.Errorf("the Solidity of %s does not declare the fallback", l.name))
+	}
+	if parsed.HasReceive() && !regexp.MustCompile(`\breceive\s*\(`).MatchString(sol) {
+		errs = append(errs, fmt.Errorf("the Solidity of %s does not declare receive", l.name))
+	}
+	return errs
+}
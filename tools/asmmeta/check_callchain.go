@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	chainCall = iota
+	chainDelegatecall
+	chainStaticcall
+)
+
+// chainModel computes what hop does to a set of CallChain copies.
+type chainModel struct {
+	peers  map[common.Address][]common.Address
+	counts map[common.Address]map[uint64]uint64
+}
+
+// hop runs hop on the storage of a copy, which DELEGATECALL keeps for the
+// whole chain.
+func (m *chainModel) hop(storage common.Address, depth, fanout, mode, key uint64) uint64 {
+	if mode != chainStaticcall {
+		m.counts[storage][key]++
+	}
+	sum := m.counts[storage][key]
+	if depth == 0 {
+		return sum
+	}
+	peers := m.peers[storage]
+	for i := uint64(0); i < fanout; i++ {
+		next := peers[(depth+i)%uint64(len(peers))]
+		if mode == chainDelegatecall {
+			next = storage
+		}
+		sum += m.hop(next, depth-1, fanout, mode, key)
+	}
+	return sum
+}
+
+func init() {
+	checks["CallChain"] = func(t *tester) {
+		a, b, c := t.deploy("CallChain"), t.deploy("CallChain"), t.deploy("CallChain")
+		model := &chainModel{
+			peers: map[common.Address][]common.Address{
+				a: {b, c},
+				b: {c, a, b},
+				c: {a},
+			},
+			counts: make(map[common.Address]map[uint64]uint64),
+		}
+		for chain, peers := range model.peers {
+			t.ok(t.call(alice, chain, "setPeers", peers), "setPeers")
+			model.counts[chain] = make(map[uint64]uint64)
+		}
+		t.expect("peer 1 of b", t.view(b, "peers", big.NewInt(1)), a)
+		t.reverts(t.call(alice, b, "peers", big.NewInt(3)), "peers out of the list")
+
+		for _, hop := range []struct{ depth, fanout, mode, key uint64 }{
+			{0, 1, chainCall, 5},
+			{3, 2, chainCall, 7},
+			{3, 2, chainDelegatecall, 7},
+			{3, 3, chainStaticcall, 7},
+			{2, 1, chainStaticcall, 8},
+		} {
+			what := fmt.Sprintf("hop(%d, %d, %d, %d)", hop.depth, hop.fanout, hop.mode, hop.key)
+			r := t.call(alice, a, "hop", new(big.Int).SetUint64(hop.depth), new(big.Int).SetUint64(hop.fanout), new(big.Int).SetUint64(hop.mode), new(big.Int).SetUint64(hop.key))
+			t.ok(r, what)
+			sum := model.hop(a, hop.depth, hop.fanout, hop.mode, hop.key)
+			if len(r.out) == 1 {
+				t.expect(what, r.out[0], sum)
+			}
+			for chain, counts := range model.counts {
+				t.expect("counts after "+what, t.view(chain, "counts", new(big.Int).SetUint64(hop.key)), counts[hop.key])
+			}
+		}
+		t.reverts(t.call(alice, a, "hop", big.NewInt(1), big.NewInt(1), big.NewInt(3), big.NewInt(7)), "hop of an unknown mode")
+		t.reverts(t.send(alice, a, big.NewInt(1), "hop", big.NewInt(0), big.NewInt(1), big.NewInt(0), big.NewInt(7)), "hop with value")
+
+		// A peer failing fails the hop with its reason, and a peer returning
+		// anything but one word fails it too
+		reverter := t.deployCode(deployable([]byte{
+			0x63, 0xde, 0xad, 0xbe, 0xef, 0x5f, 0x52, // 0xdeadbeef 0 MSTORE
+			0x60, 0x04, 0x60, 0x1c, 0xfd, // 4 28 REVERT
+		}))
+		d := t.deploy("CallChain")
+		t.ok(t.call(alice, d, "setPeers", []common.Address{reverter}), "setPeers")
+		r := t.call(alice, d, "hop", big.NewInt(1), big.NewInt(1), big.NewInt(chainCall), big.NewInt(7))
+		t.reverts(r, "hop of a failing peer")
+		if !bytes.Equal(r.ret, []byte{0xde, 0xad, 0xbe, 0xef}) {
+			t.errorf("hop of a failing peer reverted with %x, want the reason of the peer", r.ret)
+		}
+		t.ok(t.call(alice, d, "setPeers", []common.Address{t.deploy("NoOp")}), "setPeers")
+		t.reverts(t.call(alice, d, "hop", big.NewInt(1), big.NewInt(1), big.NewInt(chainCall), big.NewInt(7)), "hop of a peer returning nothing")
+		t.expect("counts after the failing hops", t.view(d, "counts", big.NewInt(7)), 0)
+
+		// A shorter list leaves the tail in storage, which an empty list
+		// still calls
+		t.ok(t.call(alice, d, "setPeers", []common.Address{a, b}), "setPeers")
+		t.ok(t.call(alice, d, "setPeers", []common.Address{}), "setPeers of nothing")
+		base := crypto.Keccak256Hash(common.Hash{}.Bytes()).Big()
+		t.expect("stale peer", t.storage(d, common.BigToHash(new(big.Int).Add(base, big.NewInt(1)))), b.Big())
+		t.reverts(t.call(alice, d, "peers", big.NewInt(0)), "peers of an empty list")
+		t.ok(t.call(alice, d, "hop", big.NewInt(1), big.NewInt(1), big.NewInt(chainCall), big.NewInt(7)), "hop of an empty list")
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func init() {
+	checks["ComputeBench"] = func(t *tester) {
+		bench := t.deploy("ComputeBench")
+		for _, rounds := range []int64{0, 1, 50} {
+			t.expect("keccakLoop", t.view(bench, "keccakLoop", big.NewInt(rounds)), keccakLoop(rounds))
+			t.expect("arithLoop", t.view(bench, "arithLoop", big.NewInt(rounds)), arithLoop(rounds))
+		}
+		for _, c := range []struct{ words, rounds int64 }{{1, 0}, {1, 5}, {3, 10}, {64, 500}} {
+			t.expect("memoryLoop", t.view(bench, "memoryLoop", big.NewInt(c.words), big.NewInt(c.rounds)), memoryLoop(c.words, c.rounds))
+		}
+		t.reverts(t.call(alice, bench, "memoryLoop", big.NewInt(0), big.NewInt(1)), "memoryLoop of no words")
+		t.reverts(t.send(alice, bench, big.NewInt(1), "keccakLoop", big.NewInt(1)), "keccakLoop with value")
+
+		// Only the number of words sets the memory expansion, so the gas grows
+		// with it for the same rounds
+		small := t.call(alice, bench, "memoryLoop", big.NewInt(1), big.NewInt(10))
+		large := t.call(alice, bench, "memoryLoop", big.NewInt(1000), big.NewInt(10))
+		if small.gas >= large.gas {
+			t.errorf("memoryLoop of 1000 words used %d gas, no more than the %d of 1 word", large.gas, small.gas)
+		}
+	}
+}
+
+var word = new(big.Int).Lsh(big.NewInt(1), 256)
+
+func keccakLoop(rounds int64) [32]byte {
+	var h common.Hash
+	for i := int64(0); i < rounds; i++ {
+		h = crypto.Keccak256Hash(h.Bytes(), common.BigToHash(big.NewInt(i)).Bytes())
+	}
+	return h
+}
+
+func arithLoop(rounds int64) *big.Int {
+	n, _ := new(big.Int).SetString("ffffffffffffffffffffffffffffff61", 16)
+	p, _ := new(big.Int).SetString("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", 16)
+	k, _ := new(big.Int).SetString("9e3779b97f4a7c15", 16)
+	x := big.NewInt(rounds)
+	for i := int64(0); i < rounds; i++ {
+		bi := big.NewInt(i)
+		x.Mul(x, k).Mod(x, word)
+		x.Add(x, bi).Mod(x, n)
+		x.Mul(x, x).Mod(x, p)
+		x.Xor(x, new(big.Int).Rsh(x, 7))
+		x.Add(x, new(big.Int).Exp(bi, big.NewInt(3), word)).Mod(x, word)
+		x.Add(x, new(big.Int).Div(x, big.NewInt(3))).Mod(x, word)
+	}
+	return x
+}
+
+func memoryLoop(words, rounds int64) *big.Int {
+	mem := make([]*big.Int, words)
+	for i := range mem {
+		mem[i] = new(big.Int)
+	}
+	mem[words-1].SetInt64(1)
+	sum := new(big.Int)
+	for i := int64(0); i < rounds; i++ {
+		w := mem[i*7919%words]
+		w.Add(w, big.NewInt(i))
+		sum.Add(sum, w)
+	}
+	return sum
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func init() {
+	checks["Contention"] = func(t *tester) {
+		contention := t.deploy("Contention")
+		t.ok(t.call(alice, contention, "touch", big.NewInt(7)), "touch")
+		t.ok(t.call(bob, contention, "touch", big.NewInt(7)), "touch")
+		t.ok(t.call(bob, contention, "touch", big.NewInt(8)), "touch")
+		t.expect("slots of 7", t.view(contention, "slots", big.NewInt(7)), 2)
+		t.expect("slots of 8", t.view(contention, "slots", big.NewInt(8)), 1)
+		t.expect("slots of 9", t.view(contention, "slots", big.NewInt(9)), 0)
+		t.expect("storage of key 7", t.storage(contention, mappingSlot(common.BigToHash(big.NewInt(7)), 0)), 2)
+
+		t.reverts(t.send(alice, contention, big.NewInt(1), "touch", big.NewInt(7)), "touch with value")
+
+		t.cfg.State.SetState(contention, mappingSlot(common.BigToHash(big.NewInt(7)), 0), common.MaxHash)
+		t.ok(t.call(alice, contention, "touch", big.NewInt(7)), "touch at the maximum")
+		t.expect("wrapped slots of 7", t.view(contention, "slots", big.NewInt(7)), 0)
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func init() {
+	checks["Counter"] = func(t *tester) {
+		counter := t.deploy("Counter")
+		t.ok(t.call(alice, counter, "increment"), "increment")
+		t.ok(t.call(bob, counter, "increment"), "increment")
+		t.expect("count", t.view(counter, "count"), 2)
+
+		t.ok(t.call(alice, counter, "incrementSender"), "incrementSender")
+		t.ok(t.call(alice, counter, "incrementSender"), "incrementSender")
+		t.ok(t.call(bob, counter, "incrementSender"), "incrementSender")
+		t.expect("counts of alice", t.view(counter, "counts", alice), 2)
+		t.expect("counts of bob", t.view(counter, "counts", bob), 1)
+		t.expect("count", t.view(counter, "count"), 2)
+
+		t.reverts(t.send(alice, counter, big.NewInt(1), "increment"), "increment with value")
+		t.reverts(t.raw(alice, counter, new(big.Int), []byte{1, 2, 3, 4}), "unknown selector")
+
+		// The counter wraps like the unchecked Solidity
+		t.cfg.State.SetState(counter, common.Hash{}, common.MaxHash)
+		t.ok(t.call(alice, counter, "increment"), "increment at the maximum")
+		t.expect("wrapped count", t.view(counter, "count"), 0)
+	}
+}
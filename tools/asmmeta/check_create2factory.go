@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func init() {
+	checks["Create2Factory"] = func(t *tester) {
+		factory := t.deploy("Create2Factory")
+		initCode := listings["Counter"].code
+		salt := common.HexToHash("0x01")
+
+		r := t.call(alice, factory, "deploy", salt, initCode)
+		t.ok(r, "deploy")
+		want := crypto.CreateAddress2(factory, salt, crypto.Keccak256(initCode))
+		if len(r.out) == 1 {
+			t.expect("deployed", r.out[0], want)
+		}
+		if code := t.cfg.State.GetCode(want); !bytes.Equal(code, initCode[len(initCode)-len(code):]) || len(code) == 0 {
+			t.errorf("deployed code %x is not the runtime code of %x", code, initCode)
+		}
+
+		t.reverts(t.call(alice, factory, "deploy", salt, initCode), "deploy with a used salt")
+		t.ok(t.call(bob, factory, "deploy", common.HexToHash("0x02"), initCode), "deploy with another salt")
+
+		// Init code reverting makes the deployment fail
+		t.reverts(t.call(alice, factory, "deploy", common.HexToHash("0x03"), []byte{0x5f, 0x5f, 0xfd}), "deploy of reverting init code")
+		t.reverts(t.send(alice, factory, big.NewInt(1), "deploy", common.HexToHash("0x04"), initCode), "deploy with value")
+	}
+}
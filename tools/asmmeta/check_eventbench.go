@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func init() {
+	checks["EventBench"] = func(t *tester) {
+		bench := t.deploy("EventBench")
+		for topics := int64(0); topics <= 4; topics++ {
+			what := fmt.Sprintf("emitLogs of %d topics", topics)
+			r := t.call(alice, bench, "emitLogs", big.NewInt(3), big.NewInt(topics), big.NewInt(40), big.NewInt(100))
+			t.ok(r, what)
+			if len(r.logs) != 3 {
+				t.errorf("%s: got %d logs, want 3", what, len(r.logs))
+				continue
+			}
+			for j, l := range r.logs {
+				var want []common.Hash
+				for k := int64(0); k < topics; k++ {
+					want = append(want, common.BigToHash(big.NewInt(100+int64(j)*4+k)))
+				}
+				t.expect(what+", topics", l.Topics, want)
+				if l.Address != bench || !bytes.Equal(l.Data, make([]byte, 40)) {
+					t.errorf("%s: got log of %v with data %x, want 40 zero bytes of %v", what, l.Address, l.Data, bench)
+				}
+			}
+		}
+		r := t.call(alice, bench, "emitLogs", big.NewInt(0), big.NewInt(4), big.NewInt(40), big.NewInt(100))
+		t.ok(r, "emitLogs of no logs")
+		t.expect("logs of emitLogs of no logs", len(r.logs), 0)
+		t.reverts(t.call(alice, bench, "emitLogs", big.NewInt(1), big.NewInt(5), big.NewInt(0), big.NewInt(0)), "emitLogs of 5 topics")
+		t.reverts(t.send(alice, bench, big.NewInt(1), "emitLogs", big.NewInt(1), big.NewInt(0), big.NewInt(0), big.NewInt(0)), "emitLogs with value")
+
+		// The topics wrap like the unchecked Solidity
+		max := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+		r = t.call(alice, bench, "emitLogs", big.NewInt(2), big.NewInt(1), big.NewInt(0), max)
+		t.ok(r, "emitLogs of the maximum seed")
+		if len(r.logs) == 2 {
+			t.expect("wrapped topic", r.logs[1].Topics, []common.Hash{common.BigToHash(big.NewInt(3))})
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+func init() {
+	checks["FailBench"] = func(t *tester) {
+		bench := t.deploy("FailBench")
+		t.ok(t.call(alice, bench, "succeed"), "succeed")
+		t.expect("count", t.view(bench, "count"), 1)
+		t.reverts(t.call(alice, bench, "fail"), "fail")
+		t.expect("count after fail", t.view(bench, "count"), 1)
+
+		r := t.call(alice, bench, "burn")
+		if !errors.Is(r.err, vm.ErrOutOfGas) || r.gas != callGasLimit {
+			t.errorf("burn: want all the gas to run out, got %v after %d gas", r.err, r.gas)
+		}
+		t.expect("count after burn", t.view(bench, "count"), 1)
+
+		t.ok(t.call(alice, bench, "nest", big.NewInt(3), false), "nest")
+		t.expect("count after nest", t.view(bench, "count"), 2)
+		t.ok(t.call(alice, bench, "nest", big.NewInt(0), false), "nest without depth")
+		t.expect("count after nest without depth", t.view(bench, "count"), 3)
+		t.reverts(t.call(alice, bench, "nest", big.NewInt(3), true), "nest failing at the bottom")
+		t.reverts(t.call(alice, bench, "nest", big.NewInt(0), true), "nest failing without depth")
+		t.expect("count after the failing nests", t.view(bench, "count"), 3)
+
+		// Every level is a call, so deeper nests cost more
+		shallow := t.call(alice, bench, "nest", big.NewInt(1), false)
+		deep := t.call(alice, bench, "nest", big.NewInt(10), false)
+		if deep.gas <= shallow.gas+9*100 {
+			t.errorf("nest of depth 10 used %d gas, want 9 calls more than the %d of depth 1", deep.gas, shallow.gas)
+		}
+
+		t.reverts(t.send(alice, bench, big.NewInt(1), "succeed"), "succeed with value")
+
+		t.cfg.State.SetState(bench, common.Hash{}, common.MaxHash)
+		t.ok(t.call(alice, bench, "succeed"), "succeed at the maximum")
+		t.expect("wrapped count", t.view(bench, "count"), 0)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+type multicallCall struct {
+	Target   common.Address
+	CallData []byte
+}
+
+func init() {
+	checks["Multicall"] = func(t *tester) {
+		multicall := t.deploy("Multicall")
+		first, second := t.deploy("Counter"), t.deploy("Counter")
+		increment := crypto.Keccak256([]byte("increment()"))[:4]
+		incrementSender := crypto.Keccak256([]byte("incrementSender()"))[:4]
+		count := common.Hash{}
+		countOfMulticall := mappingSlot(common.BytesToHash(multicall.Bytes()), 1)
+
+		t.ok(t.call(alice, multicall, "aggregate", []multicallCall{
+			{first, increment},
+			{second, incrementSender},
+			{first, increment},
+		}), "aggregate")
+		t.expect("count of the first counter", t.storage(first, count), 2)
+		t.expect("count of the multicall in the second counter", t.storage(second, countOfMulticall), 1)
+		t.ok(t.call(alice, multicall, "aggregate", []multicallCall{}), "aggregate of nothing")
+
+		// A failing call reverts them all with its reason
+		reverter := t.deployCode(deployable([]byte{
+			0x63, 0xde, 0xad, 0xbe, 0xef, 0x5f, 0x52, // 0xdeadbeef 0 MSTORE
+			0x60, 0x04, 0x60, 0x1c, 0xfd, // 4 28 REVERT
+		}))
+		r := t.call(alice, multicall, "aggregate", []multicallCall{
+			{first, increment},
+			{reverter, nil},
+		})
+		t.reverts(r, "aggregate with a failing call")
+		if !bytes.Equal(r.ret, []byte{0xde, 0xad, 0xbe, 0xef}) {
+			t.errorf("aggregate with a failing call reverted with %x, want the reason of the call", r.ret)
+		}
+		t.expect("count of the first counter after the revert", t.storage(first, count), 2)
+
+		t.reverts(t.send(alice, multicall, big.NewInt(1), "aggregate", []multicallCall{}), "aggregate with value")
+	}
+}
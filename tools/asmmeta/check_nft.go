@@ -0,0 +1,100 @@
+package main
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func init() {
+	checks["BenchERC721"] = func(t *tester) {
+		nft := t.deploy("BenchERC721")
+		r := t.call(bob, nft, "mint", alice, big.NewInt(1))
+		t.ok(r, "mint")
+		transfers := t.events(r, "Transfer")
+		t.expect("Transfer of mint", transfers, []map[string]interface{}{{"from": common.Address{}, "to": alice, "tokenId": 1}})
+		t.expect("owner of 1", t.view(nft, "ownerOf", big.NewInt(1)), alice)
+		t.expect("balance of alice", t.view(nft, "balanceOf", alice), 1)
+		t.expect("balance slot of alice", t.storage(nft, mappingSlot(common.BytesToHash(alice.Bytes()), 1)), 1)
+		t.reverts(t.call(bob, nft, "mint", bob, big.NewInt(1)), "mint of a minted token")
+		t.reverts(t.call(bob, nft, "mint", common.Address{}, big.NewInt(2)), "mint to the zero address")
+
+		r = t.call(alice, nft, "mintBatch", bob, big.NewInt(10), big.NewInt(3))
+		t.ok(r, "mintBatch")
+		t.expect("Transfers of mintBatch", t.events(r, "Transfer"), []map[string]interface{}{
+			{"from": common.Address{}, "to": bob, "tokenId": 10},
+			{"from": common.Address{}, "to": bob, "tokenId": 11},
+			{"from": common.Address{}, "to": bob, "tokenId": 12},
+		})
+		t.expect("owner of 12", t.view(nft, "ownerOf", big.NewInt(12)), bob)
+		t.expect("balance of bob", t.view(nft, "balanceOf", bob), 3)
+		t.reverts(t.call(alice, nft, "mintBatch", bob, big.NewInt(12), big.NewInt(2)), "mintBatch over a minted token")
+		t.expect("owner of 13", t.view(nft, "ownerOf", big.NewInt(13)), common.Address{})
+		t.reverts(t.call(alice, nft, "mintBatch", common.Address{}, big.NewInt(20), big.NewInt(2)), "mintBatch to the zero address")
+
+		r = t.call(alice, nft, "transferFrom", alice, bob, big.NewInt(1))
+		t.ok(r, "transferFrom")
+		t.expect("Transfer of transferFrom", t.events(r, "Transfer"), []map[string]interface{}{{"from": alice, "to": bob, "tokenId": 1}})
+		t.expect("owner of 1", t.view(nft, "ownerOf", big.NewInt(1)), bob)
+		t.expect("balance of alice", t.view(nft, "balanceOf", alice), 0)
+		t.expect("balance of bob", t.view(nft, "balanceOf", bob), 4)
+		t.reverts(t.call(alice, nft, "transferFrom", bob, alice, big.NewInt(10)), "transferFrom by another sender")
+		t.reverts(t.call(bob, nft, "transferFrom", bob, alice, big.NewInt(99)), "transferFrom of a token of someone else")
+		t.reverts(t.call(bob, nft, "transferFrom", bob, common.Address{}, big.NewInt(10)), "transferFrom to the zero address")
+		t.reverts(t.send(bob, nft, big.NewInt(1), "mint", bob, big.NewInt(30)), "mint with value")
+
+		// The end of the batch wraps like the unchecked Solidity, minting
+		// nothing but still adding the count to the balance
+		holder := common.HexToAddress("0x2000")
+		max := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+		t.ok(t.call(alice, nft, "mintBatch", holder, max, big.NewInt(2)), "mintBatch past the maximum")
+		t.expect("balance after the wrapped mintBatch", t.view(nft, "balanceOf", holder), 2)
+		t.expect("owner of the maximum", t.view(nft, "ownerOf", max), common.Address{})
+	}
+
+	checks["BenchERC1155"] = func(t *tester) {
+		nft := t.deploy("BenchERC1155")
+		r := t.call(bob, nft, "mint", alice, big.NewInt(5), big.NewInt(100))
+		t.ok(r, "mint")
+		t.expect("TransferSingle of mint", t.events(r, "TransferSingle"), []map[string]interface{}{
+			{"operator": bob, "from": common.Address{}, "to": alice, "id": 5, "value": 100},
+		})
+		t.expect("balance of alice", t.view(nft, "balanceOf", alice, big.NewInt(5)), 100)
+		balanceSlot := crypto.Keccak256Hash(common.BytesToHash(alice.Bytes()).Bytes(), mappingSlot(common.BigToHash(big.NewInt(5)), 0).Bytes())
+		t.expect("balance slot of alice", t.storage(nft, balanceSlot), 100)
+		t.reverts(t.call(bob, nft, "mint", common.Address{}, big.NewInt(5), big.NewInt(1)), "mint to the zero address")
+
+		r = t.call(alice, nft, "safeTransferFrom", alice, bob, big.NewInt(5), big.NewInt(30), []byte{})
+		t.ok(r, "safeTransferFrom")
+		t.expect("TransferSingle of safeTransferFrom", t.events(r, "TransferSingle"), []map[string]interface{}{
+			{"operator": alice, "from": alice, "to": bob, "id": 5, "value": 30},
+		})
+		t.expect("balance of alice", t.view(nft, "balanceOf", alice, big.NewInt(5)), 70)
+		t.expect("balance of bob", t.view(nft, "balanceOf", bob, big.NewInt(5)), 30)
+		t.reverts(t.call(bob, nft, "safeTransferFrom", alice, bob, big.NewInt(5), big.NewInt(1), []byte{}), "safeTransferFrom by another sender")
+		t.reverts(t.call(alice, nft, "safeTransferFrom", alice, bob, big.NewInt(5), big.NewInt(71), []byte{}), "safeTransferFrom of more than the balance")
+		t.reverts(t.call(alice, nft, "safeTransferFrom", alice, common.Address{}, big.NewInt(5), big.NewInt(1), []byte{}), "safeTransferFrom to the zero address")
+
+		t.ok(t.call(alice, nft, "mint", alice, big.NewInt(6), big.NewInt(10)), "mint")
+		ids := []*big.Int{big.NewInt(5), big.NewInt(6)}
+		r = t.call(alice, nft, "safeBatchTransferFrom", alice, bob, ids, []*big.Int{big.NewInt(20), big.NewInt(10)}, []byte{})
+		t.ok(r, "safeBatchTransferFrom")
+		t.expect("TransferBatch", t.events(r, "TransferBatch"), []map[string]interface{}{
+			{"operator": alice, "from": alice, "to": bob, "ids": ids, "values": []*big.Int{big.NewInt(20), big.NewInt(10)}},
+		})
+		t.expect("balance of alice in 5", t.view(nft, "balanceOf", alice, big.NewInt(5)), 50)
+		t.expect("balance of alice in 6", t.view(nft, "balanceOf", alice, big.NewInt(6)), 0)
+		t.expect("balance of bob in 5", t.view(nft, "balanceOf", bob, big.NewInt(5)), 50)
+		t.expect("balance of bob in 6", t.view(nft, "balanceOf", bob, big.NewInt(6)), 10)
+		t.reverts(t.call(alice, nft, "safeBatchTransferFrom", alice, bob, ids, []*big.Int{big.NewInt(1)}, []byte{}), "safeBatchTransferFrom with more ids than amounts")
+		t.reverts(t.call(alice, nft, "safeBatchTransferFrom", alice, bob, ids, []*big.Int{big.NewInt(1), big.NewInt(1)}, []byte{}), "safeBatchTransferFrom of more than the balance")
+		t.expect("balance of alice in 5 after the revert", t.view(nft, "balanceOf", alice, big.NewInt(5)), 50)
+		t.reverts(t.call(bob, nft, "safeBatchTransferFrom", alice, bob, ids, []*big.Int{big.NewInt(1), big.NewInt(0)}, []byte{}), "safeBatchTransferFrom by another sender")
+		t.reverts(t.send(alice, nft, big.NewInt(1), "mint", alice, big.NewInt(5), big.NewInt(1)), "mint with value")
+
+		max := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+		t.ok(t.call(alice, nft, "mint", bob, big.NewInt(6), max), "mint past the maximum")
+		t.expect("wrapped balance of bob", t.view(nft, "balanceOf", bob, big.NewInt(6)), 9)
+	}
+}
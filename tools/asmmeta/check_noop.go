@@ -0,0 +1,19 @@
+package main
+
+import (
+	"bytes"
+	"math/big"
+)
+
+func init() {
+	checks["NoOp"] = func(t *tester) {
+		noop := t.deploy("NoOp")
+		for _, input := range [][]byte{nil, {0}, bytes.Repeat([]byte{0xff}, 4096)} {
+			r := t.raw(alice, noop, new(big.Int), input)
+			t.ok(r, "call")
+			t.expect("gas of a call", r.gas, 0)
+		}
+		t.ok(t.raw(alice, noop, big.NewInt(5), []byte{1, 2, 3}), "call with value")
+		t.expect("balance", t.balance(noop), 5)
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func init() {
+	checks["PrecompileBench"] = func(t *tester) {
+		bench := t.deploy("PrecompileBench")
+		identity := common.BytesToAddress([]byte{4})
+		sha256 := common.BytesToAddress([]byte{2})
+		bn256Add := common.BytesToAddress([]byte{6})
+
+		once := t.call(alice, bench, "run", identity, big.NewInt(1), []byte("hello"))
+		t.ok(once, "run of identity")
+		many := t.call(alice, bench, "run", identity, big.NewInt(100), []byte("hello"))
+		t.ok(many, "run of identity")
+		if many.gas < once.gas+99*15 {
+			t.errorf("100 calls of identity used %d gas, want at least 99 calls more than the %d of one", many.gas, once.gas)
+		}
+		t.ok(t.call(alice, bench, "run", sha256, big.NewInt(3), bytes.Repeat([]byte{1}, 1000)), "run of sha256")
+		t.ok(t.call(alice, bench, "run", sha256, big.NewInt(0), []byte{}), "run of nothing")
+
+		// A point which is not on the curve fails the precompile
+		bad := bytes.Repeat([]byte{1}, 128)
+		t.reverts(t.call(alice, bench, "run", bn256Add, big.NewInt(1), bad), "run of a failing precompile")
+		t.ok(t.call(alice, bench, "run", bn256Add, big.NewInt(0), bad), "run of a failing precompile no times")
+
+		// The calls are static, so a target changing state fails
+		counter := t.deploy("Counter")
+		t.reverts(t.call(alice, bench, "run", counter, big.NewInt(1), crypto.Keccak256([]byte("increment()"))[:4]), "run of a state change")
+		t.reverts(t.send(alice, bench, big.NewInt(1), "run", identity, big.NewInt(1), []byte{}), "run with value")
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func init() {
+	checks["StateBench"] = func(t *tester) {
+		bench := t.deploy("StateBench")
+		t.ok(t.call(alice, bench, "write", big.NewInt(10), big.NewInt(3), big.NewInt(7)), "write")
+		for slot := int64(9); slot < 14; slot++ {
+			want := 7
+			if slot == 9 || slot == 13 {
+				want = 0
+			}
+			t.expect("slot after write", t.storage(bench, common.BigToHash(big.NewInt(slot))), want)
+		}
+		t.expect("read", t.view(bench, "read", big.NewInt(9), big.NewInt(5)), 21)
+
+		t.ok(t.call(alice, bench, "clear", big.NewInt(11), big.NewInt(1)), "clear")
+		t.expect("cleared slot", t.storage(bench, common.BigToHash(big.NewInt(11))), 0)
+		t.expect("read after clear", t.view(bench, "read", big.NewInt(10), big.NewInt(3)), 14)
+		t.ok(t.call(alice, bench, "clear", big.NewInt(10), big.NewInt(0)), "clear of nothing")
+		t.expect("read after clearing nothing", t.view(bench, "read", big.NewInt(10), big.NewInt(3)), 14)
+
+		t.reverts(t.send(alice, bench, big.NewInt(1), "write", big.NewInt(0), big.NewInt(1), big.NewInt(1)), "write with value")
+
+		// The sum wraps like the Solidity assembly
+		max := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+		t.ok(t.call(alice, bench, "write", big.NewInt(100), big.NewInt(2), max), "write of the maximum")
+		t.expect("wrapped read", t.view(bench, "read", big.NewInt(100), big.NewInt(2)), new(big.Int).Sub(max, big.NewInt(1)))
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"math/big"
+)
+
+func init() {
+	checks["WETH"] = func(t *tester) {
+		weth := t.deploy("WETH")
+		r := t.raw(alice, weth, big.NewInt(10), nil)
+		t.ok(r, "receive")
+		t.expect("Deposit of receive", t.events(r, "Deposit"), []map[string]interface{}{{"dst": alice, "wad": 10}})
+		r = t.send(bob, weth, big.NewInt(5), "deposit")
+		t.ok(r, "deposit")
+		t.expect("Deposit of deposit", t.events(r, "Deposit"), []map[string]interface{}{{"dst": bob, "wad": 5}})
+		t.expect("balance of alice", t.view(weth, "balanceOf", alice), 10)
+		t.expect("balance of bob", t.view(weth, "balanceOf", bob), 5)
+		t.expect("totalSupply", t.view(weth, "totalSupply"), 15)
+
+		before := t.balance(alice)
+		r = t.call(alice, weth, "withdraw", big.NewInt(4))
+		t.ok(r, "withdraw")
+		t.expect("Withdrawal", t.events(r, "Withdrawal"), []map[string]interface{}{{"src": alice, "wad": 4}})
+		t.expect("balance of alice", t.view(weth, "balanceOf", alice), 6)
+		t.expect("value withdrawn", new(big.Int).Sub(t.balance(alice), before), 4)
+		t.expect("totalSupply", t.view(weth, "totalSupply"), 11)
+		t.reverts(t.call(alice, weth, "withdraw", big.NewInt(7)), "withdraw of more than the balance")
+		t.reverts(t.send(alice, weth, big.NewInt(1), "withdraw", big.NewInt(1)), "withdraw with value")
+		t.reverts(t.raw(alice, weth, new(big.Int), []byte{1, 2, 3, 4}), "unknown selector")
+
+		// The value goes out with the stipend of transfer, which is too little
+		// to write storage, and a failing recipient fails the withdrawal
+		plain := t.deployCode(deployable([]byte{0x00}))
+		t.ok(t.send(plain, weth, big.NewInt(3), "deposit"), "deposit of a contract")
+		t.ok(t.call(plain, weth, "withdraw", big.NewInt(3)), "withdraw to a contract")
+		t.expect("value withdrawn to the contract", t.balance(plain), 3)
+		for _, runtime := range [][]byte{
+			{0x60, 0x01, 0x5f, 0x55, 0x00}, // 1 0 SSTORE STOP
+			{0x5f, 0x5f, 0xfd},             // 0 DUP1 REVERT
+		} {
+			recipient := t.deployCode(deployable(runtime))
+			t.ok(t.send(recipient, weth, big.NewInt(3), "deposit"), "deposit of a contract")
+			t.reverts(t.call(recipient, weth, "withdraw", big.NewInt(3)), "withdraw to a contract")
+			t.expect("balance of the contract", t.view(weth, "balanceOf", recipient), 3)
+		}
+	}
+}
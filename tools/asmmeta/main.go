@@ -0,0 +1,294 @@
+// asmmeta assembles the hand-written contracts in contracts/asm and writes
+// their bytecode and ABI to lib/contract_meta_data, like
+// generate_contract_meta_data.sh does for the output of solc.
+//
+// Every contracts/asm/<package>/<Name>.asm listing becomes the variables
+// <Name>ABI, read from <Name>.abi, and <Name>Bin of package <package>. A
+// listing is a sequence of whitespace separated tokens, ";" starts a comment:
+//
+//	ADD, SLOAD, ...   an opcode
+//	42, 0xff          the shortest PUSH of the number
+//	SEL(f(uint256))   PUSH4 of the selector of the signature
+//	TOPIC(E(address)) PUSH32 of the hash of the event signature
+//	name:             a JUMPDEST
+//	@name             PUSH2 of the offset of the JUMPDEST
+//
+// The bytecode deploys the assembled runtime code as is, there is no
+// constructor.
+//
+// With -check nothing is written: asmmeta fails unless the committed meta data
+// is up to date, the listings and the readable Solidity next to them declare
+// every function and event of the ABI, and every contract passes its behaviour
+// check, which runs it in an in-memory EVM through its ABI.
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	asmDir  = "contracts/asm"
+	metaDir = "lib/contract_meta_data"
+)
+
+const (
+	opPush1    = 0x60
+	opPush2    = 0x61
+	opPush4    = 0x63
+	opPush32   = 0x7f
+	opJumpdest = 0x5b
+	opDup1     = 0x80
+	opCodecopy = 0x39
+	opReturn   = 0xf3
+)
+
+var opcodes = map[string]byte{
+	"STOP": 0x00, "ADD": 0x01, "MUL": 0x02, "SUB": 0x03, "DIV": 0x04, "SDIV": 0x05,
+	"MOD": 0x06, "SMOD": 0x07, "ADDMOD": 0x08, "MULMOD": 0x09, "EXP": 0x0a, "SIGNEXTEND": 0x0b,
+	"LT": 0x10, "GT": 0x11, "SLT": 0x12, "SGT": 0x13, "EQ": 0x14, "ISZERO": 0x15,
+	"AND": 0x16, "OR": 0x17, "XOR": 0x18, "NOT": 0x19, "BYTE": 0x1a, "SHL": 0x1b,
+	"SHR": 0x1c, "SAR": 0x1d, "SHA3": 0x20, "KECCAK256": 0x20,
+	"ADDRESS": 0x30, "BALANCE": 0x31, "ORIGIN": 0x32, "CALLER": 0x33, "CALLVALUE": 0x34,
+	"CALLDATALOAD": 0x35, "CALLDATASIZE": 0x36, "CALLDATACOPY": 0x37, "CODESIZE": 0x38,
+	"CODECOPY": 0x39, "GASPRICE": 0x3a, "EXTCODESIZE": 0x3b, "EXTCODECOPY": 0x3c,
+	"RETURNDATASIZE": 0x3d, "RETURNDATACOPY": 0x3e, "EXTCODEHASH": 0x3f,
+	"BLOCKHASH": 0x40, "COINBASE": 0x41, "TIMESTAMP": 0x42, "NUMBER": 0x43,
+	"PREVRANDAO": 0x44, "GASLIMIT": 0x45, "CHAINID": 0x46, "SELFBALANCE": 0x47,
+	"BASEFEE": 0x48, "BLOBHASH": 0x49, "BLOBBASEFEE": 0x4a,
+	"POP": 0x50, "MLOAD": 0x51, "MSTORE": 0x52, "MSTORE8": 0x53, "SLOAD": 0x54,
+	"SSTORE": 0x55, "JUMP": 0x56, "JUMPI": 0x57, "PC": 0x58, "MSIZE": 0x59,
+	"GAS": 0x5a, "JUMPDEST": 0x5b, "TLOAD": 0x5c, "TSTORE": 0x5d, "MCOPY": 0x5e,
+	"PUSH0": 0x5f,
+	"LOG0":  0xa0, "LOG1": 0xa1, "LOG2": 0xa2, "LOG3": 0xa3, "LOG4": 0xa4,
+	"CREATE": 0xf0, "CALL": 0xf1, "CALLCODE": 0xf2, "RETURN": 0xf3, "DELEGATECALL": 0xf4,
+	"CREATE2": 0xf5, "STATICCALL": 0xfa, "REVERT": 0xfd, "INVALID": 0xfe, "SELFDESTRUCT": 0xff,
+}
+
+func init() {
+	for i := 0; i < 16; i++ {
+		opcodes[fmt.Sprintf("DUP%d", i+1)] = 0x80 + byte(i)
+		opcodes[fmt.Sprintf("SWAP%d", i+1)] = 0x90 + byte(i)
+	}
+}
+
+type instruction struct {
+	op    byte
+	data  []byte
+	label string // pushes the offset of the label
+	def   string // defines the label
+}
+
+func (in instruction) size() int {
+	switch {
+	case in.def != "":
+		return 1
+	case in.label != "":
+		return 3
+	default:
+		return 1 + len(in.data)
+	}
+}
+
+func assemble(src string) ([]byte, error) {
+	var code []instruction
+	for n, line := range strings.Split(src, "\n") {
+		line, _, _ = strings.Cut(line, ";")
+		for _, token := range strings.Fields(line) {
+			in, err := parse(token)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n+1, err)
+			}
+			code = append(code, in)
+		}
+	}
+
+	labels := make(map[string]int)
+	pc := 0
+	for _, in := range code {
+		if in.def != "" {
+			labels[in.def] = pc
+		}
+		pc += in.size()
+	}
+
+	out := make([]byte, 0, pc)
+	for _, in := range code {
+		switch {
+		case in.def != "":
+			out = append(out, opJumpdest)
+		case in.label != "":
+			offset, ok := labels[in.label]
+			if !ok {
+				return nil, fmt.Errorf("unknown label %s", in.label)
+			}
+			out = append(out, opPush2, byte(offset>>8), byte(offset))
+		default:
+			out = append(out, in.op)
+			out = append(out, in.data...)
+		}
+	}
+	return out, nil
+}
+
+func parse(token string) (instruction, error) {
+	switch {
+	case strings.HasSuffix(token, ":"):
+		return instruction{def: strings.TrimSuffix(token, ":")}, nil
+	case strings.HasPrefix(token, "@"):
+		return instruction{label: token[1:]}, nil
+	case strings.HasPrefix(token, "SEL(") && strings.HasSuffix(token, ")"):
+		return instruction{op: opPush4, data: crypto.Keccak256([]byte(token[4 : len(token)-1]))[:4]}, nil
+	case strings.HasPrefix(token, "TOPIC(") && strings.HasSuffix(token, ")"):
+		return instruction{op: opPush32, data: crypto.Keccak256([]byte(token[6 : len(token)-1]))}, nil
+	case token[0] >= '0' && token[0] <= '9':
+		n, ok := new(big.Int).SetString(token, 0)
+		if !ok || n.BitLen() > 256 {
+			return instruction{}, fmt.Errorf("bad number %s", token)
+		}
+		data := n.Bytes()
+		if len(data) == 0 {
+			data = []byte{0}
+		}
+		return instruction{op: opPush1 + byte(len(data)-1), data: data}, nil
+	}
+	op, ok := opcodes[token]
+	if !ok {
+		return instruction{}, fmt.Errorf("unknown opcode %s", token)
+	}
+	return instruction{op: op}, nil
+}
+
+// deployable prefixes the runtime code with init code returning it.
+func deployable(runtime []byte) []byte {
+	n := len(runtime)
+	init := []byte{
+		opPush2, byte(n >> 8), byte(n),
+		opDup1,
+		opPush1, 0, // offset of the runtime code, set below
+		opPush1, 0,
+		opCodecopy,
+		opPush1, 0,
+		opReturn,
+	}
+	init[5] = byte(len(init))
+	return append(init, runtime...)
+}
+
+// listing is an assembled contract.
+type listing struct {
+	pkg, name string
+	src       string
+	abi       string
+	code      []byte
+}
+
+func main() {
+	root := flag.String("root", ".", "root of the repository")
+	check := flag.Bool("check", false, "check the meta data, the ABIs and the behaviour of the contracts instead of writing the meta data")
+	flag.Parse()
+
+	dirs, err := filepath.Glob(filepath.Join(*root, asmDir, "*"))
+	if err != nil {
+		log.Fatalf("Failed to list %s: %v", asmDir, err)
+	}
+	type pkgListings struct {
+		dir, pkg string
+		listings []*listing
+	}
+	var pkgs []pkgListings
+	for _, dir := range dirs {
+		pkg := filepath.Base(dir)
+		l, err := readListings(pkg, dir)
+		if err != nil {
+			log.Fatalf("Failed to assemble %s: %v", dir, err)
+		}
+		pkgs = append(pkgs, pkgListings{dir, pkg, l})
+		for _, l := range l {
+			listings[l.name] = l
+		}
+	}
+
+	var failed bool
+	for _, p := range pkgs {
+		metaFile := filepath.Join(*root, metaDir, p.pkg, "meta_data.go")
+		if !*check {
+			err = writeMetaData(metaFile, p.pkg, p.listings)
+			if err != nil {
+				log.Fatalf("Failed to generate the meta data of %s: %v", p.dir, err)
+			}
+			continue
+		}
+		for _, err := range checkPackage(p.dir, metaFile, p.pkg, p.listings) {
+			log.Printf("%s: %v", p.pkg, err)
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func readListings(pkg, dir string) ([]*listing, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.asm"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	var listings []*listing
+	for _, file := range files {
+		abi, err := os.ReadFile(strings.TrimSuffix(file, ".asm") + ".abi")
+		if err != nil {
+			return nil, err
+		}
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		runtime, err := assemble(string(src))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		listings = append(listings, &listing{
+			pkg:  pkg,
+			name: strings.TrimSuffix(filepath.Base(file), ".asm"),
+			src:  string(src),
+			abi:  strings.TrimSpace(string(abi)),
+			code: deployable(runtime),
+		})
+	}
+	return listings, nil
+}
+
+func metaData(pkg string, listings []*listing) string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "// This file is generated by \"make asm-metadata\" from the hand-written assembly\n")
+	fmt.Fprintf(&out, "// in %s/%s, please do not edit it\n\n", asmDir, pkg)
+	fmt.Fprintf(&out, "package %s\n\n", pkg)
+	for _, l := range listings {
+		fmt.Fprintf(&out, "var %sABI = %q\n", l.name, l.abi)
+	}
+	out.WriteString("\n")
+	for _, l := range listings {
+		fmt.Fprintf(&out, "var %sBin = \"%s\"\n", l.name, hex.EncodeToString(l.code))
+	}
+	return out.String()
+}
+
+func writeMetaData(file, pkg string, listings []*listing) error {
+	err := os.MkdirAll(filepath.Dir(file), 0755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, []byte(metaData(pkg, listings)), 0644)
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+)
+
+// callGasLimit is the gas of every call, like a transaction of the benchmark
+// could have.
+const callGasLimit = 30_000_000
+
+var (
+	deployer = common.HexToAddress("0x1000")
+	alice    = common.HexToAddress("0x1001")
+	bob      = common.HexToAddress("0x1002")
+)
+
+// tester runs a contract in an in-memory EVM through its ABI and collects the
+// failures of its behaviour check. A check has to call every function and see
+// every event of the ABI.
+type tester struct {
+	name    string
+	abi     abi.ABI
+	cfg     *runtime.Config
+	called  map[string]bool
+	emitted map[string]bool
+	errs    []error
+}
+
+// result is the outcome of a call.
+type result struct {
+	out  []interface{}
+	ret  []byte
+	logs []*types.Log
+	gas  uint64
+	err  error
+}
+
+func newTester(l *listing, parsed abi.ABI) *tester {
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	if err != nil {
+		panic(err)
+	}
+	return &tester{
+		name:    l.name,
+		abi:     parsed,
+		cfg:     &runtime.Config{State: statedb, GasLimit: callGasLimit, BlockNumber: big.NewInt(1)},
+		called:  make(map[string]bool),
+		emitted: make(map[string]bool),
+	}
+}
+
+func (t *tester) errorf(format string, args ...interface{}) {
+	t.errs = append(t.errs, fmt.Errorf("%s: "+format, append([]interface{}{t.name}, args...)...))
+}
+
+// finish returns the failures, including the functions and events the check
+// did not exercise.
+func (t *tester) finish() []error {
+	for name := range t.abi.Methods {
+		if !t.called[name] {
+			t.errorf("the check does not call %s", name)
+		}
+	}
+	for name := range t.abi.Events {
+		if !t.emitted[name] {
+			t.errorf("the check does not see %s", name)
+		}
+	}
+	if t.abi.HasFallback() && !t.called["fallback"] {
+		t.errorf("the check does not call the fallback")
+	}
+	if t.abi.HasReceive() && !t.called["receive"] {
+		t.errorf("the check does not call receive")
+	}
+	return t.errs
+}
+
+// deploy deploys the named listing, which is usually the checked contract.
+func (t *tester) deploy(name string) common.Address {
+	return t.deployCode(listings[name].code)
+}
+
+// deployCode deploys init code.
+func (t *tester) deployCode(code []byte) common.Address {
+	t.cfg.Origin = deployer
+	t.cfg.Value = new(big.Int)
+	_, addr, _, err := runtime.Create(code, t.cfg)
+	if err != nil {
+		t.errorf("deploying: %v", err)
+	}
+	return addr
+}
+
+// call calls a function of the checked contract without value.
+func (t *tester) call(from, to common.Address, method string, args ...interface{}) *result {
+	return t.send(from, to, new(big.Int), method, args...)
+}
+
+// send calls a function of the checked contract with value.
+func (t *tester) send(from, to common.Address, value *big.Int, method string, args ...interface{}) *result {
+	input, err := t.abi.Pack(method, args...)
+	if err != nil {
+		t.errorf("packing %s: %v", method, err)
+		return &result{err: err}
+	}
+	t.called[method] = true
+	r := t.exec(from, to, value, input)
+	if r.err == nil && len(t.abi.Methods[method].Outputs) > 0 {
+		r.out, err = t.abi.Unpack(method, r.ret)
+		if err != nil {
+			t.errorf("unpacking the output of %s: %v", method, err)
+		}
+	}
+	return r
+}
+
+// raw calls an address with calldata, which reaches the fallback or receive
+// of the checked contract unless it starts with one of its selectors.
+func (t *tester) raw(from, to common.Address, value *big.Int, input []byte) *result {
+	if _, err := t.abi.MethodById(input); err != nil {
+		if len(input) == 0 && t.abi.HasReceive() {
+			t.called["receive"] = true
+		} else {
+			t.called["fallback"] = true
+		}
+	}
+	return t.exec(from, to, value, input)
+}
+
+func (t *tester) exec(from, to common.Address, value *big.Int, input []byte) *result {
+	t.cfg.Origin = from
+	t.cfg.Value = value
+	t.cfg.State.AddBalance(from, uint256.MustFromBig(value), tracing.BalanceChangeUnspecified)
+	before := len(t.cfg.State.Logs())
+	ret, left, err := runtime.Call(to, input, t.cfg)
+	return &result{
+		ret:  ret,
+		logs: t.cfg.State.Logs()[before:],
+		gas:  callGasLimit - left,
+		err:  err,
+	}
+}
+
+// view returns the single output of a function.
+func (t *tester) view(to common.Address, method string, args ...interface{}) interface{} {
+	r := t.call(deployer, to, method, args...)
+	if r.err != nil {
+		t.errorf("%s: %v", method, r.err)
+		return nil
+	}
+	if len(r.out) != 1 {
+		t.errorf("%s returned %d values", method, len(r.out))
+		return nil
+	}
+	return r.out[0]
+}
+
+// ok expects the call to succeed.
+func (t *tester) ok(r *result, what string) {
+	if r.err != nil {
+		t.errorf("%s: %v", what, r.err)
+	}
+}
+
+// reverts expects the call to revert.
+func (t *tester) reverts(r *result, what string) {
+	if !errors.Is(r.err, vm.ErrExecutionReverted) {
+		t.errorf("%s: want a revert, got %v", what, r.err)
+	}
+}
+
+// expect compares values by their printed form, which makes big.Int,
+// addresses and plain numbers comparable.
+func (t *tester) expect(what string, got, want interface{}) {
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.errorf("%s: got %v, want %v", what, got, want)
+	}
+}
+
+// events decodes the logs of an event of the checked contract.
+func (t *tester) events(r *result, name string) []map[string]interface{} {
+	event := t.abi.Events[name]
+	var indexed abi.Arguments
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	var out []map[string]interface{}
+	for _, l := range r.logs {
+		if len(l.Topics) == 0 || l.Topics[0] != event.ID {
+			continue
+		}
+		fields := make(map[string]interface{})
+		err := t.abi.UnpackIntoMap(fields, name, l.Data)
+		if err == nil {
+			err = abi.ParseTopicsIntoMap(fields, indexed, l.Topics[1:])
+		}
+		if err != nil {
+			t.errorf("decoding %s: %v", name, err)
+			continue
+		}
+		out = append(out, fields)
+		t.emitted[name] = true
+	}
+	return out
+}
+
+func (t *tester) storage(addr common.Address, slot common.Hash) *big.Int {
+	return t.cfg.State.GetState(addr, slot).Big()
+}
+
+func (t *tester) balance(addr common.Address) *big.Int {
+	return t.cfg.State.GetBalance(addr).ToBig()
+}
+
+// mappingSlot is the storage slot of a key of a Solidity mapping at a slot.
+func mappingSlot(key common.Hash, slot uint64) common.Hash {
+	return crypto.Keccak256Hash(key.Bytes(), common.BigToHash(new(big.Int).SetUint64(slot)).Bytes())
+}